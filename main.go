@@ -1,3 +1,5 @@
+//go:build !headless
+
 package main
 
 import (
@@ -75,23 +77,19 @@ func main() {
 	dir := 1.0
 
 	// draw entity
-	renderLoop.Runnables = []notacore.Runnable{
-		func() error {
-			texture.Bind(0)
-			entity.Draw(win.RunTime.Renderer)
-			return nil
-		},
-	}
+	renderLoop.Add(func() error {
+		texture.Bind(0)
+		entity.Draw(win.RunTime.Renderer)
+		return nil
+	})
 
 	// rotate and move entity
-	logicLoop.Runnables = []notacore.Runnable{
-		func() error {
-			entity.Rotate(0.01)
+	logicLoop.Add(func() error {
+		entity.Rotate(0.01)
 
-			entity.Move(delta.Mul(float32(dir)))
-			return nil
-		},
-	}
+		entity.Move(delta.Mul(float32(dir)))
+		return nil
+	})
 
 	if err := engine.Run(); err != nil {
 		log.Fatal("Engine run failed:", err)
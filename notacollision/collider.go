@@ -25,6 +25,22 @@ type PolygonCollider struct {
 	Vertices []notamath.Po2
 }
 
+// NewConvexHullCollider wraps the convex hull of points, for example a traced sprite outline
+func NewConvexHullCollider(points []notamath.Po2) *PolygonCollider {
+	return &PolygonCollider{Vertices: notamath.ConvexHull2(points, false)}
+}
+
+// NewPolygonColliders builds one collider per outline of a polygon operation result, holes are joined to their
+// outline by a zero width bridge
+func NewPolygonColliders(rings [][]notamath.Po2) []*PolygonCollider {
+	shapes := notamath.GroupRings(rings)
+	colliders := make([]*PolygonCollider, len(shapes))
+	for i, s := range shapes {
+		colliders[i] = &PolygonCollider{Vertices: s.Bridged()}
+	}
+	return colliders
+}
+
 func (c *CircleCollider) AABB() AABBCollider {
 	return AABBCollider{
 		Min: notamath.Vec2{
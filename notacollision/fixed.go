@@ -0,0 +1,182 @@
+package notacollision
+
+import "NotaborEngine/notamath"
+
+// Fixed point colliders mirror the float ones, use them when the simulation must stay in lockstep across machines
+
+type FixedAABBCollider struct {
+	Min notamath.FixedVec2
+	Max notamath.FixedVec2
+}
+
+type FixedCollider interface {
+	AABB() FixedAABBCollider
+	Move(delta notamath.FixedVec2)
+}
+
+type FixedCircleCollider struct {
+	Center notamath.FixedVec2
+	Radius notamath.Fixed
+}
+
+type FixedPolygonCollider struct {
+	Vertices []notamath.FixedVec2
+}
+
+func (c *FixedCircleCollider) AABB() FixedAABBCollider {
+	r := notamath.FixedVec2{X: c.Radius, Y: c.Radius}
+	return FixedAABBCollider{
+		Min: c.Center.Sub(r),
+		Max: c.Center.Add(r),
+	}
+}
+
+func (c *FixedCircleCollider) Move(delta notamath.FixedVec2) {
+	c.Center = c.Center.Add(delta)
+}
+
+func (p *FixedPolygonCollider) AABB() FixedAABBCollider {
+	if len(p.Vertices) == 0 {
+		return FixedAABBCollider{}
+	}
+
+	box := FixedAABBCollider{Min: p.Vertices[0], Max: p.Vertices[0]}
+	for _, v := range p.Vertices[1:] {
+		box.Min.X = min(box.Min.X, v.X)
+		box.Min.Y = min(box.Min.Y, v.Y)
+		box.Max.X = max(box.Max.X, v.X)
+		box.Max.Y = max(box.Max.Y, v.Y)
+	}
+	return box
+}
+
+func (p *FixedPolygonCollider) Move(delta notamath.FixedVec2) {
+	for i := range p.Vertices {
+		p.Vertices[i] = p.Vertices[i].Add(delta)
+	}
+}
+
+// Transform applies m to every vertex in place
+func (p *FixedPolygonCollider) Transform(m notamath.FixedMat3) {
+	for i := range p.Vertices {
+		p.Vertices[i] = m.TransformPoint(p.Vertices[i])
+	}
+}
+
+func FixedAABBIntersects(a, b FixedAABBCollider) bool {
+	return a.Min.X <= b.Max.X &&
+		a.Max.X >= b.Min.X &&
+		a.Min.Y <= b.Max.Y &&
+		a.Max.Y >= b.Min.Y
+}
+
+func FixedIntersects(a, b FixedCollider) bool {
+	if !FixedAABBIntersects(a.AABB(), b.AABB()) {
+		return false
+	}
+
+	switch a := a.(type) {
+	case *FixedCircleCollider:
+		switch b := b.(type) {
+		case *FixedCircleCollider:
+			r := a.Radius + b.Radius
+			return a.Center.DistanceSquared(b.Center) <= r.Mul(r)
+		case *FixedPolygonCollider:
+			return fixedCircleVsPolygon(a, b)
+		}
+	case *FixedPolygonCollider:
+		switch b := b.(type) {
+		case *FixedCircleCollider:
+			return fixedCircleVsPolygon(b, a)
+		case *FixedPolygonCollider:
+			return fixedPolygonVsPolygon(a, b)
+		}
+	}
+
+	return false
+}
+
+func fixedPolygonVsPolygon(a, b *FixedPolygonCollider) bool {
+	nA := len(a.Vertices)
+	nB := len(b.Vertices)
+	if nA == 0 || nB == 0 {
+		return false
+	}
+
+	for i := 0; i < nA; i++ {
+		a1 := a.Vertices[i]
+		a2 := a.Vertices[(i+1)%nA]
+
+		for j := 0; j < nB; j++ {
+			if fixedSegmentsIntersect(a1, a2, b.Vertices[j], b.Vertices[(j+1)%nB]) {
+				return true
+			}
+		}
+	}
+
+	return fixedPointInPolygon(a.Vertices[0], b.Vertices) || fixedPointInPolygon(b.Vertices[0], a.Vertices)
+}
+
+func fixedCircleVsPolygon(c *FixedCircleCollider, p *FixedPolygonCollider) bool {
+	r2 := c.Radius.Mul(c.Radius)
+	n := len(p.Vertices)
+
+	for i := 0; i < n; i++ {
+		closest := fixedClosestPointOnSegment(p.Vertices[i], p.Vertices[(i+1)%n], c.Center)
+		if c.Center.DistanceSquared(closest) <= r2 {
+			return true
+		}
+	}
+
+	return fixedPointInPolygon(c.Center, p.Vertices)
+}
+
+// fixedSegmentsIntersect needs no epsilon since FixedOrientSign keeps the whole cross product
+func fixedSegmentsIntersect(p1, p2, q1, q2 notamath.FixedVec2) bool {
+	o1 := notamath.FixedOrientSign(p1, p2, q1)
+	o2 := notamath.FixedOrientSign(p1, p2, q2)
+	o3 := notamath.FixedOrientSign(q1, q2, p1)
+	o4 := notamath.FixedOrientSign(q1, q2, p2)
+
+	if o1*o2 < 0 && o3*o4 < 0 {
+		return true
+	}
+
+	return (o1 == 0 && fixedOnSegment(p1, p2, q1)) ||
+		(o2 == 0 && fixedOnSegment(p1, p2, q2)) ||
+		(o3 == 0 && fixedOnSegment(q1, q2, p1)) ||
+		(o4 == 0 && fixedOnSegment(q1, q2, p2))
+}
+
+func fixedOnSegment(a, b, p notamath.FixedVec2) bool {
+	return p.X >= min(a.X, b.X) && p.X <= max(a.X, b.X) &&
+		p.Y >= min(a.Y, b.Y) && p.Y <= max(a.Y, b.Y)
+}
+
+func fixedPointInPolygon(point notamath.FixedVec2, poly []notamath.FixedVec2) bool {
+	inside := false
+	n := len(poly)
+
+	for i := 0; i < n; i++ {
+		pi := poly[i]
+		pj := poly[(i+n-1)%n]
+
+		if (pi.Y > point.Y) != (pj.Y > point.Y) &&
+			point.X < (pj.X-pi.X).Mul((point.Y-pi.Y).Div(pj.Y-pi.Y))+pi.X {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+func fixedClosestPointOnSegment(a, b, p notamath.FixedVec2) notamath.FixedVec2 {
+	ab := b.Sub(a)
+	l := ab.LenSquared()
+	if l == 0 {
+		return a
+	}
+
+	t := p.Sub(a).Dot(ab).Div(l).Clamp(0, notamath.FixedOne)
+	return a.Add(ab.Mul(t))
+}
@@ -0,0 +1,183 @@
+package notacore
+
+import (
+	"NotaborEngine/notagl"
+	"NotaborEngine/notamath"
+	"math"
+)
+
+// FreeFlyController moves a Camera3D like a spectator: WASD to move, Space and Left Control to rise and sink,
+// Left Shift to go faster and the mouse to look around while the right button is held
+type FreeFlyController struct {
+	Camera *notagl.Camera3D
+	Input  *InputManager
+
+	Forward, Back, Left, Right, Up, Down, Fast *InputSignal
+	Look                                       *InputSignal // nil looks around all the time
+
+	Speed          float32 // units per second
+	FastMultiplier float32
+	Sensitivity    float32 // radians per pixel of cursor movement
+
+	yaw, pitch float32
+}
+
+// NewFreeFlyController binds the default keys on im and starts from the camera's current orientation
+func NewFreeFlyController(cam *notagl.Camera3D, im *InputManager) *FreeFlyController {
+	c := &FreeFlyController{
+		Camera:         cam,
+		Input:          im,
+		Forward:        &InputSignal{},
+		Back:           &InputSignal{},
+		Left:           &InputSignal{},
+		Right:          &InputSignal{},
+		Up:             &InputSignal{},
+		Down:           &InputSignal{},
+		Fast:           &InputSignal{},
+		Look:           &InputSignal{},
+		Speed:          5,
+		FastMultiplier: 3,
+		Sensitivity:    0.003,
+	}
+
+	im.BindInput(KeyW, c.Forward)
+	im.BindInput(KeyS, c.Back)
+	im.BindInput(KeyA, c.Left)
+	im.BindInput(KeyD, c.Right)
+	im.BindInput(KeySpace, c.Up)
+	im.BindInput(KeyLeftControl, c.Down)
+	im.BindInput(KeyLeftShift, c.Fast)
+	im.BindInput(MouseRight, c.Look)
+
+	// recover yaw and pitch from where the camera looks
+	f := cam.Forward()
+	c.yaw = float32(math.Atan2(float64(-f.X), float64(-f.Z)))
+	c.pitch = float32(math.Asin(float64(clampUnit(f.Y))))
+	return c
+}
+
+// Update moves the camera by one step of dt seconds
+func (c *FreeFlyController) Update(dt float32) {
+	if c.Look == nil || c.Look.Down() {
+		d := c.Input.CursorDelta()
+		c.yaw -= d.X * c.Sensitivity
+		c.pitch -= d.Y * c.Sensitivity
+		c.pitch = min(max(c.pitch, -math.Pi/2+0.01), math.Pi/2-0.01)
+	}
+	c.Camera.Rotation = notamath.QuatFromEuler(c.pitch, c.yaw, 0)
+
+	var move notamath.Vec3
+	if signalDown(c.Forward) {
+		move = move.Add(c.Camera.Forward())
+	}
+	if signalDown(c.Back) {
+		move = move.Sub(c.Camera.Forward())
+	}
+	if signalDown(c.Right) {
+		move = move.Add(c.Camera.Right())
+	}
+	if signalDown(c.Left) {
+		move = move.Sub(c.Camera.Right())
+	}
+	if signalDown(c.Up) {
+		move = move.Add(notamath.Vec3{Y: 1})
+	}
+	if signalDown(c.Down) {
+		move = move.Sub(notamath.Vec3{Y: 1})
+	}
+
+	speed := c.Speed
+	if signalDown(c.Fast) {
+		speed *= c.FastMultiplier
+	}
+	c.Camera.Position = c.Camera.Position.Add(move.Normalize().Mul(speed * dt))
+}
+
+// Runnable updates the controller once per tick of the loop, using unscaled time so the camera keeps its speed in
+// slow motion
+func (c *FreeFlyController) Runnable(loop *FixedHzLoop) Runnable {
+	return func() error {
+		c.Update(loop.UnscaledDt())
+		return nil
+	}
+}
+
+// OrbitController circles a Camera3D around a target: drag with the left mouse button to rotate, Q and E to
+// zoom out and in
+type OrbitController struct {
+	Camera *notagl.Camera3D
+	Input  *InputManager
+
+	Target   notamath.Vec3
+	Yaw      float32 // radians around +Y
+	Pitch    float32 // radians above the horizon
+	Distance float32
+
+	Rotate, ZoomIn, ZoomOut *InputSignal
+
+	Sensitivity float32 // radians per pixel of cursor movement
+	ZoomSpeed   float32 // fraction of the distance per second
+	MinDistance float32
+	MaxDistance float32
+}
+
+func NewOrbitController(cam *notagl.Camera3D, im *InputManager, target notamath.Vec3, distance float32) *OrbitController {
+	c := &OrbitController{
+		Camera:      cam,
+		Input:       im,
+		Target:      target,
+		Pitch:       0.4,
+		Distance:    distance,
+		Rotate:      &InputSignal{},
+		ZoomIn:      &InputSignal{},
+		ZoomOut:     &InputSignal{},
+		Sensitivity: 0.005,
+		ZoomSpeed:   1.5,
+		MinDistance: 0.5,
+		MaxDistance: 1000,
+	}
+
+	im.BindInput(MouseLeft, c.Rotate)
+	im.BindInput(KeyE, c.ZoomIn)
+	im.BindInput(KeyQ, c.ZoomOut)
+
+	cam.Orbit(target, c.Yaw, c.Pitch, distance)
+	return c
+}
+
+func (c *OrbitController) Update(dt float32) {
+	if signalDown(c.Rotate) {
+		d := c.Input.CursorDelta()
+		c.Yaw -= d.X * c.Sensitivity
+		c.Pitch += d.Y * c.Sensitivity
+	}
+	c.Pitch = min(max(c.Pitch, -math.Pi/2+0.01), math.Pi/2-0.01)
+
+	zoom := float32(1)
+	if signalDown(c.ZoomIn) {
+		zoom -= c.ZoomSpeed * dt
+	}
+	if signalDown(c.ZoomOut) {
+		zoom += c.ZoomSpeed * dt
+	}
+	c.Distance = min(max(c.Distance*max(zoom, 0.01), c.MinDistance), c.MaxDistance)
+
+	c.Camera.Orbit(c.Target, c.Yaw, c.Pitch, c.Distance)
+}
+
+// Runnable updates the controller once per tick of the loop, using unscaled time so the camera keeps its speed in
+// slow motion
+func (c *OrbitController) Runnable(loop *FixedHzLoop) Runnable {
+	return func() error {
+		c.Update(loop.UnscaledDt())
+		return nil
+	}
+}
+
+func signalDown(s *InputSignal) bool {
+	return s != nil && s.Down()
+}
+
+func clampUnit(v float32) float32 {
+	return min(max(v, -1), 1)
+}
@@ -0,0 +1,132 @@
+package notacore
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock tells loops, actions and the engine what time it is. RealClock is used when none is set, tests use a
+// ManualClock to move time by hand
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// RealClock is the wall clock
+var RealClock Clock = realClock{}
+
+// clockOrReal returns c, RealClock when it is nil
+func clockOrReal(c Clock) Clock {
+	if c == nil {
+		return RealClock
+	}
+	return c
+}
+
+// ManualClock only moves when Advance is called. A FixedHzLoop started on it gets no goroutine: Advance runs its
+// ticks on the caller's goroutine and returns once every tick that became due has run, so a test can tell exactly
+// which runnables ran on which tick
+type ManualClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	loops []*FixedHzLoop // started on this clock, in start order
+
+	advancing sync.Mutex
+}
+
+// NewManualClock returns a clock standing at start, a zero start stands at the Unix epoch
+func NewManualClock(start time.Time) *ManualClock {
+	if start.IsZero() {
+		start = time.Unix(0, 0)
+	}
+	return &ManualClock{now: start}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d. It stops at every moment a loop started on the clock is due to tick and runs
+// that tick, loops due at the same moment tick in the order they were started. Ticks are never late or dropped,
+// single steps requested with Step run right away, Advance(0) runs only those
+func (c *ManualClock) Advance(d time.Duration) {
+	c.advancing.Lock()
+	defer c.advancing.Unlock()
+
+	c.mu.Lock()
+	target := c.now.Add(max(d, 0))
+	c.mu.Unlock()
+
+	// loops that ran at a moment without ticking, their next tick is due a rounding error later
+	stalled := map[*FixedHzLoop]time.Time{}
+
+	for {
+		now := c.Now()
+		var next *FixedHzLoop
+		var due time.Time
+		for _, l := range c.running() {
+			l.mu.Lock()
+			wait := l.untilNextTick(now)
+			l.mu.Unlock()
+			if wait < 0 {
+				continue
+			}
+			if wait == 0 {
+				if at, ok := stalled[l]; ok && at.Equal(now) {
+					wait = 1
+				}
+			}
+			at := now.Add(wait)
+			if at.After(target) {
+				continue
+			}
+			if next == nil || at.Before(due) {
+				next, due = l, at
+			}
+		}
+		if next == nil {
+			break
+		}
+
+		c.mu.Lock()
+		c.now = due
+		c.mu.Unlock()
+
+		before := next.Counters()
+		next.drive(due)
+		if next.Counters() == before {
+			stalled[next] = due
+		}
+	}
+
+	c.mu.Lock()
+	c.now = target
+	c.mu.Unlock()
+}
+
+// start hands l to the clock instead of a goroutine
+func (c *ManualClock) start(l *FixedHzLoop) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !slices.Contains(c.loops, l) {
+		c.loops = append(c.loops, l)
+	}
+}
+
+// running returns the loops that are still started and forgets the stopped ones
+func (c *ManualClock) running() []*FixedHzLoop {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loops = slices.DeleteFunc(c.loops, func(l *FixedHzLoop) bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.stop == nil
+	})
+	return slices.Clone(c.loops)
+}
@@ -1,15 +1,16 @@
 package notacore
 
 import (
-	"os"
-	"path/filepath"
-	"runtime"
+	"math"
+	"sync"
+	"sync/atomic"
 	"time"
-
-	"github.com/go-gl/gl/v4.6-core/gl"
-	"github.com/go-gl/glfw/v3.3/glfw"
 )
 
+// InputRunnable names the runnable the engine adds to every logic loop to update the input signals, order against
+// it with After(InputRunnable)
+const InputRunnable = "notacore.input"
+
 type Settings struct {
 	Vsync bool
 }
@@ -20,36 +21,63 @@ type Engine struct {
 	WindowManager *windowManager
 	Input         *InputManager
 
-	running bool
+	// OnError is called for every failing runnable of every loop, after the loop's own OnError
+	OnError func(err *RunnableError)
+
+	// Clock is where the engine reads the time, RealClock when nil. Run hands it to every loop and to the
+	// InputManager when they have no Clock of their own
+	Clock Clock
+
+	running atomic.Bool
+	errMu   sync.Mutex
+	err     error
+
+	headless  bool
+	stopConds []func() bool
 }
 
+// headlessPoll bounds how long a headless engine sleeps between frames, so Stop is noticed quickly
+const headlessPoll = 5 * time.Millisecond
+
+// Run runs all loops until every window is closed or Stop is called. It returns the error of a runnable that
+// stopped the engine through StopEngineOnError
 func (e *Engine) Run() error {
-	e.running = true
+	e.running.Store(true)
+	e.errMu.Lock()
+	e.err = nil
+	e.errMu.Unlock()
+
+	if e.Input != nil && e.Input.Clock == nil {
+		e.Input.Clock = e.Clock
+	}
 
 	// Start all logic loops
 	for _, w := range e.Windows {
 		cfg := w.GetConfig()
+		e.attachLoops(w)
 		for _, loop := range cfg.LogicLoops {
-			if e.Input != nil {
-				update := func() error {
+			if e.Input != nil && loop.Find(InputRunnable) == nil {
+				loop.Add(func() error {
 					e.Input.UpdateSignals()
 					return nil
-				}
-				loop.Runnables = append([]Runnable{update}, loop.Runnables...)
+				}).Named(InputRunnable).Order(math.MinInt)
 			}
 			loop.Start()
 		}
-		w.GetRuntime().lastRender = time.Now()
+		w.GetRuntime().lastRender = e.now()
 	}
 
-	for e.running && !e.AllWindowsClosed() {
-		e.WindowManager.PollEvents()
+	for e.running.Load() && !e.AllWindowsClosed() && !e.stopRequested() {
+		if !e.headless {
+			e.WindowManager.PollEvents()
 
-		if e.Input != nil {
-			e.Input.CaptureInputs(e.Windows)
+			if e.Input != nil {
+				e.Input.CaptureInputs(e.Windows)
+			}
 		}
 
-		now := time.Now()
+		now := e.now()
+		nextFrame := headlessPoll
 
 		for _, win := range e.Windows {
 			if win.ShouldClose() {
@@ -59,13 +87,22 @@ func (e *Engine) Run() error {
 			rt := win.GetRuntime()
 			elapsed := now.Sub(rt.lastRender)
 			if elapsed < rt.targetDt {
+				nextFrame = min(nextFrame, rt.targetDt-elapsed)
 				continue
 			}
 			rt.lastRender = now
+			rt.frameTime.add(elapsed)
 
+			start := time.Now()
 			win.MakeContextCurrent()
 			win.RunRenderer()
 			win.SwapBuffers()
+			rt.renderTime.add(time.Since(start))
+		}
+
+		// without vsync and events to wait on, a headless engine would spin
+		if e.headless {
+			time.Sleep(nextFrame)
 		}
 	}
 
@@ -75,98 +112,74 @@ func (e *Engine) Run() error {
 			loop.Stop()
 		}
 	}
-	return nil
-}
 
-func (e *Engine) AllWindowsClosed() bool {
-	for _, w := range e.Windows {
-		if !w.ShouldClose() {
-			return false
-		}
-	}
-	return true
+	e.running.Store(false)
+	e.errMu.Lock()
+	defer e.errMu.Unlock()
+	return e.err
 }
 
-func (e *Engine) Shutdown() {
-	glfw.Terminate()
+func (e *Engine) now() time.Time {
+	return clockOrReal(e.Clock).Now()
 }
 
-func (e *Engine) InitPlatform() error {
-	runtime.LockOSThread()
-
-	if err := addNativeDLLPath(); err != nil {
-		return err
-	}
-
-	if err := glfw.Init(); err != nil {
-		return err
-	}
-
-	glfw.WindowHint(glfw.Resizable, glfw.True)
-	glfw.WindowHint(glfw.ContextVersionMajor, 4)
-	glfw.WindowHint(glfw.ContextVersionMinor, 6)
-	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
-	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
-
-	wm := &windowManager{
-		windows2D: []*GlfwWindow2D{},
-		windows3D: []*GlfwWindow3D{},
-		nextID:    0,
+// stopRequested reports whether a StopWhen condition holds
+func (e *Engine) stopRequested() bool {
+	for _, cond := range e.stopConds {
+		if cond() {
+			return true
+		}
 	}
+	return false
+}
 
-	e.WindowManager = wm
-	e.Input = &InputManager{}
-	return nil
+// Stop makes Run return after the current frame, it is safe to call from any goroutine
+func (e *Engine) Stop() {
+	e.running.Store(false)
 }
 
-func (e *Engine) CreateWindow2D(cfg WindowConfig) (*GlfwWindow2D, error) {
-	win, err := e.WindowManager.Create2D(cfg)
-	if err != nil {
-		return nil, err
+// fail stops the engine with err, the first error wins
+func (e *Engine) fail(err error) {
+	e.errMu.Lock()
+	if e.err == nil {
+		e.err = err
 	}
-	win.MakeContextCurrent()
-	if err := gl.Init(); err != nil {
-		return nil, err
-	}
-	win.RunTime.backend.Init()
-	e.Windows = append(e.Windows, win)
-	return win, nil
+	e.errMu.Unlock()
+	e.Stop()
 }
 
-func (e *Engine) CreateWindow3D(cfg WindowConfig) (*GlfwWindow3D, error) {
-	win, err := e.WindowManager.Create3D(cfg)
-	if err != nil {
-		return nil, err
+// attachLoops tells the window's loops which window and engine they belong to, for TickInfo and error handling, and
+// hands them the engine's Clock
+func (e *Engine) attachLoops(w Window) {
+	cfg := w.GetConfig()
+	for _, loop := range cfg.LogicLoops {
+		loop.mu.Lock()
+		loop.window = w
+		loop.engine = e
+		if loop.Clock == nil {
+			loop.Clock = e.Clock
+		}
+		loop.mu.Unlock()
 	}
-	win.MakeContextCurrent()
-	if err := gl.Init(); err != nil {
-		return nil, err
+	if cfg.RenderLoop != nil {
+		cfg.RenderLoop.mu.Lock()
+		if cfg.RenderLoop.Clock == nil {
+			cfg.RenderLoop.Clock = e.Clock
+		}
+		cfg.RenderLoop.window = w
+		cfg.RenderLoop.engine = e
+		if len(cfg.LogicLoops) > 0 {
+			cfg.RenderLoop.logic = cfg.LogicLoops[0]
+		}
+		cfg.RenderLoop.mu.Unlock()
 	}
-	win.RunTime.backend.Init()
-	e.Windows = append(e.Windows, win)
-	return win, nil
 }
 
-func addNativeDLLPath() error {
-	switch runtime.GOOS {
-	case "windows":
-		exeDir, err := os.Getwd()
-		if err != nil {
-			return err
-		}
-		dllDir := filepath.Join(exeDir, "notacore", "native", "windows")
-		err = os.Setenv("PATH", dllDir+";"+os.Getenv("PATH"))
-		if err != nil {
-			return err
+func (e *Engine) AllWindowsClosed() bool {
+	for _, w := range e.Windows {
+		if !w.ShouldClose() {
+			return false
 		}
-
-	case "linux":
-		// set linux paths later
-
-	case "darwin":
-	// set mac paths later
-	default:
-		// return unsupported platform error
 	}
-	return nil
+	return true
 }
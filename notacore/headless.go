@@ -0,0 +1,19 @@
+//go:build headless
+
+package notacore
+
+// Built with the headless tag notacore links neither GLFW nor OpenGL, so it builds without cgo. Only virtual windows
+// exist, use InitHeadless and CreateVirtualWindow
+
+// platformWindow is empty, there is no GLFW handle to expose
+type platformWindow interface{}
+
+// windowManager has no windows to manage
+type windowManager struct{}
+
+func (wm *windowManager) PollEvents() {}
+
+// CaptureInputs does nothing, there are no devices to read
+func (im *InputManager) CaptureInputs(windows []Window) {}
+
+func (e *Engine) Shutdown() {}
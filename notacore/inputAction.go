@@ -27,15 +27,21 @@ type Action struct {
 	Cooldown time.Duration
 	lastRun  time.Time
 
+	// Clock times the cooldown and holds. When nil the InputManager's Clock is used for actions bound with
+	// InputManager.BindAction, RealClock otherwise
+	Clock Clock
+	input *InputManager
+
 	runnables []Runnable
 }
 
 func (a *Action) ShouldRun() bool {
+	now := a.clock().Now()
 
 	a.shouldToggle()
-	a.updateHoldInformation()
+	a.updateHoldInformation(now)
 
-	if time.Since(a.lastRun) < a.Cooldown {
+	if now.Sub(a.lastRun) < a.Cooldown {
 		return false
 	}
 
@@ -56,25 +62,32 @@ func (a *Action) ShouldRun() bool {
 	}
 
 	if result {
-		a.lastRun = time.Now()
+		a.lastRun = now
 	}
 	return result
 }
 
+func (a *Action) clock() Clock {
+	if a.Clock == nil && a.input != nil {
+		return clockOrReal(a.input.Clock)
+	}
+	return clockOrReal(a.Clock)
+}
+
 func (a *Action) shouldToggle() {
 	if a.signal.Pressed() {
 		a.Toggled = !a.Toggled
 	}
 }
 
-func (a *Action) updateHoldInformation() {
+func (a *Action) updateHoldInformation(now time.Time) {
 	if a.signal.Released() {
-		a.lastRelease = time.Now()
+		a.lastRelease = now
 		a.HeldTicks = 0
 	}
 
 	if a.signal.Held() {
-		a.lastHold = time.Now()
+		a.lastHold = now
 		a.HeldTicks++
 	}
 
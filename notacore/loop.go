@@ -2,10 +2,9 @@ package notacore
 
 import (
 	"fmt"
+	"math"
 	"sync"
 	"time"
-
-	"github.com/go-gl/gl/v4.6-core/gl"
 )
 
 type Runnable func() error
@@ -13,149 +12,711 @@ type Runnable func() error
 type FixedHzLoop struct {
 	Hz float32
 
+	// MaxCatchUp is how many ticks may run back to back when the loop falls behind, the rest are dropped.
+	// 0 means DefaultMaxCatchUp
+	MaxCatchUp int
+
+	// ErrorPolicy decides what happens to a runnable that fails, DefaultErrorPolicy removes it
+	ErrorPolicy ErrorPolicy
+	// OnError is called for every failing runnable before the policy is applied, the error is printed when neither
+	// the loop nor the engine has a handler
+	OnError func(err *RunnableError)
+
+	// Clock is where the loop reads the time, RealClock when nil. Set it before Start, the engine sets its own Clock
+	// on loops that have none
+	Clock Clock
+
 	mu               sync.Mutex
-	Runnables        []Runnable
 	OneTimeRunnables []Runnable
 
+	runnables runnableList
+
+	window Window  // set by the engine
+	engine *Engine // set by the engine
+
 	stop chan struct{}
+	wake chan struct{}
 	wg   sync.WaitGroup
 
-	lastTick time.Time
-	delta    time.Duration
+	delta     time.Duration
+	started   time.Time     // clock time of Start
+	lastWake  time.Time     // clock time acc was last brought up to date
+	acc       time.Duration // scaled time not consumed by ticks yet
+	timeScale float32
+	scaleSet  bool
+	paused    bool
+	steps     int // single steps requested while paused
+
+	ticks   uint64
+	late    uint64
+	dropped uint64
+
+	overruns uint64
+	tickTime timingWindow
+	jitter   timingWindow
+	tickRate rateWindow
 
 	// monitoring
 	monitorEvery time.Duration
 	lastMonitor  time.Time
-	tickCount    uint64
 }
 
-// EnableMonitor prints actual Hz + avg tick time at the given interval.
+const DefaultMaxCatchUp = 5
+
+// LoopCounters reports how well a FixedHzLoop keeps up with wall time
+type LoopCounters struct {
+	Ticks   uint64 // ticks run
+	Late    uint64 // ticks that started a full interval or more after they were due
+	Dropped uint64 // ticks skipped because the loop was further behind than MaxCatchUp
+}
+
+// EnableMonitor prints a summary of Stats at the given interval, read Stats directly to graph or alert on it.
 // Use interval = 0 to disable.
 func (l *FixedHzLoop) EnableMonitor(interval time.Duration) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	l.monitorEvery = interval
-	l.lastMonitor = time.Now()
-	l.tickCount = 0
+	l.lastMonitor = l.now()
 }
 
 type RenderLoop struct {
-	MaxHz     float32
-	Runnables []Runnable
-	LastTime  time.Time
+	MaxHz    float32
+	LastTime time.Time
+
+	// ErrorPolicy decides what happens to a runnable that fails, DefaultErrorPolicy keeps it. StopLoopOnError halts
+	// the render loop's runnables until Resume
+	ErrorPolicy ErrorPolicy
+	// OnError is called for every failing runnable before the policy is applied
+	OnError func(err *RunnableError)
+
+	// Clock is where the render loop reads the time, RealClock when nil
+	Clock Clock
+
+	runnables runnableList
+
+	window Window       // set by the engine
+	engine *Engine      // set by the engine
+	logic  *FixedHzLoop // first logic loop of the window, source of TickInfo.Alpha
+
+	mu              sync.Mutex
+	frames          uint64
+	paused          bool
+	halted          bool
+	timeScale       float32
+	scaleSet        bool
+	dt              time.Duration // scaled
+	rawDt           time.Duration
+	elapsed         time.Duration // scaled
+	unscaledElapsed time.Duration
 }
 
+// Start runs the loop on its own goroutine. Ticks are scheduled on a fixed grid: when a tick overruns, the missed
+// ticks run back to back (up to MaxCatchUp) so simulation time keeps pace with wall time. A loop on a ManualClock
+// gets no goroutine, ManualClock.Advance runs its ticks
 func (l *FixedHzLoop) Start() {
-
 	l.mu.Lock()
 	// Prevent multiple starts
 	if l.stop != nil {
 		l.mu.Unlock()
 		return
 	}
-	l.stop = make(chan struct{})
+	stop := make(chan struct{})
+	wake := make(chan struct{}, 1)
+	l.stop = stop
+	l.wake = wake
+
+	l.delta = time.Duration(float64(time.Second) / float64(l.Hz))
+	l.started = l.now()
+	l.lastWake = l.started
+	l.acc = 0
 	l.mu.Unlock()
 
-	l.stop = make(chan struct{})
+	if mc, ok := l.Clock.(*ManualClock); ok {
+		mc.start(l)
+		return
+	}
+
 	l.wg.Add(1)
 
 	go func() {
 		defer l.wg.Done()
 
-		interval := time.Duration(float64(time.Second) / float64(l.Hz))
-		l.delta = interval
-		l.lastTick = time.Now()
-
-		ticker := time.NewTicker(interval)
-		defer ticker.Stop()
+		timer := time.NewTimer(0)
+		defer timer.Stop()
 
 		for {
-			select {
-			case <-ticker.C:
-				l.mu.Lock()
-				l.lastTick = time.Now()
-
-				otr := l.OneTimeRunnables
-				l.OneTimeRunnables = nil
-
-				atr := append([]Runnable(nil), l.Runnables...)
-				monitorEvery := l.monitorEvery
-				lastMonitor := l.lastMonitor
-				l.mu.Unlock()
-
-				for _, r := range otr {
-					if err := r(); err != nil {
-						fmt.Println(err)
-					}
+			l.mu.Lock()
+			wait := l.untilNextTick(l.now())
+			l.mu.Unlock()
+
+			switch {
+			case wait < 0:
+				select {
+				case <-wake:
+				case <-stop:
+					return
 				}
-
-				newRunnables := atr[:0]
-				for _, r := range atr {
-					if err := r(); err != nil {
-						fmt.Println(err)
-					} else {
-						newRunnables = append(newRunnables, r)
+			case wait > 0:
+				timer.Reset(wait)
+				select {
+				case <-timer.C:
+				case <-wake:
+					if !timer.Stop() {
+						<-timer.C
 					}
+				case <-stop:
+					return
 				}
-
-				l.mu.Lock()
-				l.Runnables = newRunnables
-
-				// monitor
-				l.tickCount++
-				if monitorEvery > 0 && time.Since(lastMonitor) >= monitorEvery {
-					elapsed := time.Since(lastMonitor)
-					hz := float64(l.tickCount) / elapsed.Seconds()
-					avgTick := elapsed.Seconds() * 1000.0 / float64(l.tickCount)
-					fmt.Printf("[FixedHzLoop] actual=%.1f Hz, avg=%.2f ms\n", hz, avgTick)
-					l.lastMonitor = time.Now()
-					l.tickCount = 0
+			default:
+				select {
+				case <-stop:
+					return
+				default:
 				}
-				l.mu.Unlock()
+			}
 
-			case <-l.stop:
+			if l.advance(l.now()) {
+				l.halt(stop)
 				return
 			}
 		}
 	}()
 }
 
+// drive runs what is due at now for a ManualClock, Stop waits for it like it waits for the goroutine
+func (l *FixedHzLoop) drive(now time.Time) {
+	l.mu.Lock()
+	stop := l.stop
+	if stop == nil {
+		l.mu.Unlock()
+		return
+	}
+	l.wg.Add(1)
+	l.mu.Unlock()
+	defer l.wg.Done()
+
+	if l.advance(now) {
+		l.halt(stop)
+	}
+}
+
+// halt stops the loop and its coroutines after a runnable failed under StopLoopOnError, leaving it ready for
+// another Start
+func (l *FixedHzLoop) halt(stop chan struct{}) {
+	l.mu.Lock()
+	if l.stop == stop {
+		l.stop = nil
+		l.wake = nil
+	}
+	l.mu.Unlock()
+	l.runnables.removeEndingWithLoop()
+}
+
+func (l *FixedHzLoop) now() time.Time {
+	return clockOrReal(l.Clock).Now()
+}
+
+// untilNextTick is how long the loop may sleep, negative means until woken. l.mu must be held
+func (l *FixedHzLoop) untilNextTick(now time.Time) time.Duration {
+	if l.steps > 0 {
+		return 0
+	}
+	scale := l.scale()
+	if l.paused || scale == 0 {
+		return -1
+	}
+	wait := time.Duration(math.Ceil(float64(l.delta-l.acc)/float64(scale))) - now.Sub(l.lastWake)
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// bank moves the scaled wall time since the last wake into the accumulator. l.mu must be held
+func (l *FixedHzLoop) bank(now time.Time) {
+	if l.stop == nil {
+		return
+	}
+	if !l.paused {
+		l.acc += time.Duration(math.Round(float64(now.Sub(l.lastWake)) * float64(l.scale())))
+	}
+	l.lastWake = now
+}
+
+// advance runs the requested single steps and every tick the accumulator has room for. It reports whether a
+// runnable stopped the loop
+func (l *FixedHzLoop) advance(now time.Time) bool {
+	l.mu.Lock()
+	l.bank(now)
+	steps := l.steps
+	l.steps = 0
+	maxSteps := l.MaxCatchUp
+	if maxSteps <= 0 {
+		maxSteps = DefaultMaxCatchUp
+	}
+	l.mu.Unlock()
+
+	for ; steps > 0; steps-- {
+		if l.tick(-1) {
+			return true
+		}
+	}
+
+	for i := 0; i < maxSteps; i++ {
+		l.mu.Lock()
+		if l.paused || l.acc < l.delta {
+			l.mu.Unlock()
+			break
+		}
+		// how long ago the tick was due in wall time, the accumulator is in scaled time
+		var lateness time.Duration
+		if l.scale() > 0 {
+			lateness = l.wall(l.acc - l.delta)
+		}
+		l.acc -= l.delta
+		l.mu.Unlock()
+
+		if l.tick(lateness) {
+			return true
+		}
+	}
+
+	// still behind after catching up, give up on the missed ticks instead of spiralling
+	l.mu.Lock()
+	if !l.paused && l.acc >= l.delta {
+		missed := l.acc / l.delta
+		l.acc -= missed * l.delta
+		l.dropped += uint64(missed)
+	}
+	l.mu.Unlock()
+	return false
+}
+
+// tick runs the runnables once, it reports whether a runnable stopped the loop. lateness is how long ago the tick
+// was due, negative for single steps which were never due
+func (l *FixedHzLoop) tick(lateness time.Duration) bool {
+	now := l.now()
+	start := time.Now() // tick and runnable timings are always measured on the wall clock
+
+	l.mu.Lock()
+	info := TickInfo{
+		Tick:            l.ticks,
+		Dt:              l.Dt(),
+		UnscaledDt:      l.unscaledDt(),
+		Elapsed:         l.elapsed(l.ticks + 1),
+		UnscaledElapsed: now.Sub(l.started),
+		Loop:            l,
+		Window:          l.window,
+	}
+	l.ticks++
+	interval := l.wall(l.delta)
+	if lateness >= interval {
+		l.late++
+	}
+
+	otr := l.OneTimeRunnables
+	l.OneTimeRunnables = nil
+
+	failure := runnableFailure{policy: l.ErrorPolicy, fallback: RemoveOnError, onError: l.OnError, engine: l.engine}
+	l.mu.Unlock()
+
+	l.tickRate.add(now)
+	if lateness >= 0 {
+		l.jitter.add(lateness)
+	}
+
+	stopped := false
+	for _, r := range otr {
+		if failure.run(nil, r.WithInfo(), info) {
+			stopped = true
+			break
+		}
+	}
+
+	if !stopped {
+		handles := l.runnables.snapshot()
+		if err := l.runnables.takeCycle(); err != nil {
+			failure.report(&RunnableError{Err: err, Info: info})
+		}
+		for _, h := range handles {
+			if h.Removed() {
+				continue
+			}
+			t := time.Now()
+			failed := failure.run(h, h.fn, info)
+			h.timing.add(time.Since(t))
+			if failed {
+				stopped = true
+				break
+			}
+		}
+	}
+
+	took := time.Since(start)
+	l.tickTime.add(took)
+
+	l.mu.Lock()
+	if took > interval {
+		l.overruns++
+	}
+	monitor := l.monitorEvery > 0 && now.Sub(l.lastMonitor) >= l.monitorEvery
+	if monitor {
+		l.lastMonitor = now
+	}
+	l.mu.Unlock()
+
+	if monitor {
+		st := l.Stats()
+		fmt.Printf("[FixedHzLoop] actual=%.1f Hz, tick avg=%.2f ms p99=%.2f ms, late=%d, dropped=%d, overruns=%d\n",
+			st.ActualHz, ms(st.TickTime.Avg), ms(st.TickTime.P99), st.Late, st.Dropped, st.Overruns)
+	}
+	return stopped
+}
+
+func ms(d time.Duration) float64 {
+	return d.Seconds() * 1000
+}
+
+// Counters returns the tick, late and dropped counts since the loop was created
+func (l *FixedHzLoop) Counters() LoopCounters {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return LoopCounters{Ticks: l.ticks, Late: l.late, Dropped: l.dropped}
+}
+
+// Stop stops the loop and waits for a running tick to finish, the loop's coroutines are stopped as well
 func (l *FixedHzLoop) Stop() {
-	close(l.stop)
+	l.mu.Lock()
+	stop := l.stop
+	l.stop = nil
+	l.wake = nil
+	l.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
 	l.wg.Wait()
+	l.runnables.removeEndingWithLoop()
 }
 
-func (l *FixedHzLoop) Remove(i int) {
+// Pause stops ticking, scaled time and Alpha freeze until Resume
+func (l *FixedHzLoop) Pause() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	l.bank(l.now())
+	l.paused = true
+}
 
-	last := len(l.Runnables) - 1
-	if i < 0 || i > last {
+// Resume continues ticking after Pause, the time spent paused is not caught up on. It does nothing on a loop that is
+// not paused
+func (l *FixedHzLoop) Resume() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.paused {
+		return
+	}
+	l.lastWake = l.now()
+	l.paused = false
+	l.notify()
+}
+
+func (l *FixedHzLoop) Paused() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.paused
+}
+
+// Step runs n ticks as soon as possible, meant for advancing a paused loop frame by frame
+func (l *FixedHzLoop) Step(n int) {
+	if n <= 0 {
 		return
 	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.steps += n
+	l.notify()
+}
+
+// SetTimeScale changes how fast loop time passes relative to wall time: 0.5 ticks half as often, 2 twice as often.
+// Every tick still stands for Dt seconds, so the simulation stays deterministic. 0 freezes time like Pause
+func (l *FixedHzLoop) SetTimeScale(scale float32) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bank(l.now())
+	l.timeScale = max(scale, 0)
+	l.scaleSet = true
+	l.notify()
+}
+
+// TimeScale returns the scale set by SetTimeScale, 1 by default
+func (l *FixedHzLoop) TimeScale() float32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.scale()
+}
+
+// wall converts scaled loop time to wall time, frozen time never passes. l.mu must be held
+func (l *FixedHzLoop) wall(d time.Duration) time.Duration {
+	scale := l.scale()
+	if scale == 0 {
+		return math.MaxInt64
+	}
+	return time.Duration(float64(d) / float64(scale))
+}
+
+func (l *FixedHzLoop) scale() float32 {
+	if !l.scaleSet {
+		return 1
+	}
+	return l.timeScale
+}
+
+// notify wakes the loop goroutine so it picks up a pause, step or scale change. l.mu must be held
+func (l *FixedHzLoop) notify() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Dt is the scaled time in seconds one tick stands for, always 1 / Hz
+func (l *FixedHzLoop) Dt() float32 {
+	if l.Hz <= 0 {
+		return 0
+	}
+	return 1 / l.Hz
+}
+
+// UnscaledDt is the wall time in seconds between ticks at the current time scale, 0 while time is frozen. Use it
+// for things that should ignore slow motion, like a debug camera
+func (l *FixedHzLoop) UnscaledDt() float32 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.unscaledDt()
+}
+
+func (l *FixedHzLoop) unscaledDt() float32 {
+	scale := l.scale()
+	if l.paused || scale == 0 {
+		return 0
+	}
+	return l.Dt() / scale
+}
+
+// Time is the scaled time the loop has simulated, ticks times Dt
+func (l *FixedHzLoop) Time() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.elapsed(l.ticks)
+}
+
+func (l *FixedHzLoop) elapsed(ticks uint64) time.Duration {
+	if l.Hz <= 0 {
+		return 0
+	}
+	return time.Duration(float64(ticks) * float64(time.Second) / float64(l.Hz))
+}
+
+// UnscaledTime is the wall time since the loop was started, paused time included
+func (l *FixedHzLoop) UnscaledTime() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.started.IsZero() {
+		return 0
+	}
+	return l.now().Sub(l.started)
+}
+
+// Add appends a runnable to the loop, it runs every tick until it returns an error or its handle is removed
+func (l *FixedHzLoop) Add(r Runnable) *RunnableHandle {
+	return l.runnables.add(r.WithInfo())
+}
+
+// AddTick is Add for a runnable that wants to know about the tick it runs in
+func (l *FixedHzLoop) AddTick(r TickRunnable) *RunnableHandle {
+	return l.runnables.add(r)
+}
+
+// Remove takes the runnable out of the loop, same as h.Remove()
+func (l *FixedHzLoop) Remove(h *RunnableHandle) {
+	if h != nil && h.list == &l.runnables {
+		h.Remove()
+	}
+}
+
+// Find returns the first runnable with the given name, nil if there is none
+func (l *FixedHzLoop) Find(name string) *RunnableHandle {
+	return l.runnables.find(name)
+}
 
-	l.Runnables[i] = l.Runnables[last]
-	l.Runnables[last] = nil
-	l.Runnables = l.Runnables[:last]
+// Len returns how many runnables the loop has
+func (l *FixedHzLoop) Len() int {
+	return l.runnables.len()
 }
 
+// Add appends a runnable to the render loop, it runs every frame until its handle is removed
+func (r *RenderLoop) Add(fn Runnable) *RunnableHandle {
+	return r.runnables.add(fn.WithInfo())
+}
+
+// AddTick is Add for a runnable that wants to know about the frame it runs in
+func (r *RenderLoop) AddTick(fn TickRunnable) *RunnableHandle {
+	return r.runnables.add(fn)
+}
+
+// Remove takes the runnable out of the render loop, same as h.Remove()
+func (r *RenderLoop) Remove(h *RunnableHandle) {
+	if h != nil && h.list == &r.runnables {
+		h.Remove()
+	}
+}
+
+// Find returns the first runnable with the given name, nil if there is none
+func (r *RenderLoop) Find(name string) *RunnableHandle {
+	return r.runnables.find(name)
+}
+
+func (r *RenderLoop) Len() int {
+	return r.runnables.len()
+}
+
+// Render runs the runnables, the window clears the screen before. A paused RenderLoop keeps drawing, only its
+// scaled time stops
 func (r *RenderLoop) Render() {
-	now := time.Now()
+	now := clockOrReal(r.Clock).Now()
 
-	gl.ClearColor(0.0, 0.0, 0.0, 1.0)
-	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	r.mu.Lock()
+	r.rawDt = 0
+	if !r.LastTime.IsZero() {
+		r.rawDt = now.Sub(r.LastTime)
+	}
+	r.LastTime = now
+	r.dt = 0
+	if !r.paused {
+		r.dt = time.Duration(float64(r.rawDt) * float64(r.scale()))
+	}
+	r.elapsed += r.dt
+	r.unscaledElapsed += r.rawDt
+	info := TickInfo{
+		Tick:            r.frames,
+		Dt:              float32(r.dt.Seconds()),
+		UnscaledDt:      float32(r.rawDt.Seconds()),
+		Elapsed:         r.elapsed,
+		UnscaledElapsed: r.unscaledElapsed,
+		RenderLoop:      r,
+		Window:          r.window,
+	}
+	r.frames++
+	halted := r.halted
+	logic := r.logic
+	failure := runnableFailure{policy: r.ErrorPolicy, fallback: KeepOnError, onError: r.OnError, engine: r.engine}
+	r.mu.Unlock()
+
+	if logic != nil {
+		info.Alpha = logic.Alpha(now)
+	}
 
-	for _, runnable := range r.Runnables {
-		if err := runnable(); err != nil {
-			fmt.Println("Render error:", err)
+	if !halted {
+		handles := r.runnables.snapshot()
+		if err := r.runnables.takeCycle(); err != nil {
+			failure.report(&RunnableError{Err: err, Info: info})
+		}
+		for _, h := range handles {
+			if h.Removed() {
+				continue
+			}
+			t := time.Now()
+			failed := failure.run(h, h.fn, info)
+			h.timing.add(time.Since(t))
+			if failed {
+				r.mu.Lock()
+				r.halted = true
+				r.mu.Unlock()
+				break
+			}
 		}
 	}
-	r.LastTime = now
 }
 
+// Pause freezes the render loop's scaled time while frames keep being drawn, e.g. behind a pause menu
+func (r *RenderLoop) Pause() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paused = true
+}
+
+// Resume unpauses the render loop, it also restarts the runnables of a loop halted by StopLoopOnError
+func (r *RenderLoop) Resume() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paused = false
+	r.halted = false
+}
+
+// Halted reports whether a failing runnable stopped the render loop's runnables under StopLoopOnError
+func (r *RenderLoop) Halted() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.halted
+}
+
+func (r *RenderLoop) Paused() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.paused
+}
+
+// SetTimeScale scales Dt and Time of the render loop, 1 by default
+func (r *RenderLoop) SetTimeScale(scale float32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timeScale = max(scale, 0)
+	r.scaleSet = true
+}
+
+func (r *RenderLoop) TimeScale() float32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.scale()
+}
+
+func (r *RenderLoop) scale() float32 {
+	if !r.scaleSet {
+		return 1
+	}
+	return r.timeScale
+}
+
+// Dt is the scaled time in seconds since the previous frame, 0 while paused
+func (r *RenderLoop) Dt() float32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return float32(r.dt.Seconds())
+}
+
+// UnscaledDt is the wall time in seconds since the previous frame
+func (r *RenderLoop) UnscaledDt() float32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return float32(r.rawDt.Seconds())
+}
+
+// Time is the scaled time the render loop has run for
+func (r *RenderLoop) Time() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.elapsed
+}
+
+// Alpha returns the interpolation factor between the last tick and the next one. It is measured in scaled time
+// from when the last tick was due, so it stays meaningful while catching up and freezes while paused
 func (l *FixedHzLoop) Alpha(now time.Time) float32 {
 	l.mu.Lock()
-	last := l.lastTick
+	acc := l.acc
+	if l.stop != nil && !l.paused {
+		acc += time.Duration(float64(now.Sub(l.lastWake)) * float64(l.scale()))
+	}
 	delta := l.delta
 	l.mu.Unlock()
 
@@ -163,7 +724,7 @@ func (l *FixedHzLoop) Alpha(now time.Time) float32 {
 		return 1
 	}
 
-	alpha := float32(now.Sub(last).Seconds() / delta.Seconds())
+	alpha := float32(acc.Seconds() / delta.Seconds())
 
 	if alpha < 0 {
 		return 0
@@ -0,0 +1,175 @@
+package notacore
+
+import (
+	"testing"
+	"time"
+)
+
+// manualLoop starts a 10 Hz loop, one tick every 100ms, on a ManualClock and stops it when the test ends
+func manualLoop(t *testing.T, l *FixedHzLoop) *ManualClock {
+	t.Helper()
+	clock := NewManualClock(time.Time{})
+	if l.Hz == 0 {
+		l.Hz = 10
+	}
+	l.Clock = clock
+	l.Start()
+	t.Cleanup(l.Stop)
+	return clock
+}
+
+// stall moves the clock by d and only then lets the loop run, like a loop whose goroutine was not scheduled for d
+func stall(c *ManualClock, l *FixedHzLoop, d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+	l.drive(c.Now())
+}
+
+func TestLoopTickInfo(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+
+	var infos []TickInfo
+	l.AddTick(func(info TickInfo) error {
+		infos = append(infos, info)
+		return nil
+	})
+
+	clock.Advance(350 * time.Millisecond)
+	if len(infos) != 3 {
+		t.Fatalf("%d ticks in 350ms at 10 Hz, want 3", len(infos))
+	}
+	for i, info := range infos {
+		want := time.Duration(i+1) * 100 * time.Millisecond
+		if info.Tick != uint64(i) || info.Elapsed != want || info.UnscaledElapsed != want {
+			t.Errorf("tick %d: Tick %d, Elapsed %v, UnscaledElapsed %v, want %d and %v", i, info.Tick, info.Elapsed,
+				info.UnscaledElapsed, i, want)
+		}
+		if info.Dt != 0.1 || info.Loop != l {
+			t.Errorf("tick %d: Dt %f, Loop %p, want 0.1 and %p", i, info.Dt, info.Loop, l)
+		}
+	}
+}
+
+func TestLoopMaxCatchUp(t *testing.T) {
+	l := &FixedHzLoop{MaxCatchUp: 2}
+	clock := manualLoop(t, l)
+
+	// 5.5 ticks behind: two catch up late, three are dropped and the half tick is kept
+	stall(clock, l, 550*time.Millisecond)
+	want := LoopCounters{Ticks: 2, Late: 2, Dropped: 3}
+	if got := l.Counters(); got != want {
+		t.Fatalf("after a 550ms stall: %+v, want %+v", got, want)
+	}
+
+	clock.Advance(50 * time.Millisecond)
+	want.Ticks++
+	if got := l.Counters(); got != want {
+		t.Errorf("the kept half tick did not complete on time: %+v, want %+v", got, want)
+	}
+}
+
+func TestLoopPauseStepResume(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+	ticks := func() uint64 { return l.Counters().Ticks }
+
+	clock.Advance(100 * time.Millisecond)
+	l.Pause()
+	clock.Advance(time.Second)
+	if ticks() != 1 {
+		t.Fatalf("%d ticks, a paused loop kept ticking", ticks())
+	}
+
+	l.Step(2)
+	clock.Advance(0)
+	if ticks() != 3 {
+		t.Fatalf("%d ticks after Step(2), want 3", ticks())
+	}
+
+	// the second spent paused is not caught up on
+	l.Resume()
+	clock.Advance(100 * time.Millisecond)
+	if ticks() != 4 || l.Paused() {
+		t.Fatalf("%d ticks after Resume, paused %v, want 4 and false", ticks(), l.Paused())
+	}
+
+	// resuming a running loop must not throw away the time banked towards the next tick
+	clock.Advance(50 * time.Millisecond)
+	l.Resume()
+	clock.Advance(50 * time.Millisecond)
+	if ticks() != 5 {
+		t.Errorf("%d ticks, Resume on a running loop delayed the next tick", ticks())
+	}
+}
+
+func TestLoopTimeScale(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+
+	var infos []TickInfo
+	l.AddTick(func(info TickInfo) error {
+		infos = append(infos, info)
+		return nil
+	})
+
+	l.SetTimeScale(0.5)
+	clock.Advance(100 * time.Millisecond)
+	if len(infos) != 0 {
+		t.Fatalf("a tick ran after 100ms at half speed")
+	}
+	clock.Advance(100 * time.Millisecond)
+	if len(infos) != 1 {
+		t.Fatalf("%d ticks after 200ms at half speed, want 1", len(infos))
+	}
+	if info := infos[0]; info.Dt != 0.1 || info.UnscaledDt != 0.2 || info.Elapsed != 100*time.Millisecond {
+		t.Errorf("Dt %f, UnscaledDt %f, Elapsed %v, want 0.1, 0.2, 100ms", info.Dt, info.UnscaledDt, info.Elapsed)
+	}
+
+	l.SetTimeScale(0)
+	clock.Advance(time.Second)
+	if len(infos) != 1 {
+		t.Errorf("%d ticks, time scale 0 did not freeze the loop", len(infos))
+	}
+
+	l.SetTimeScale(2)
+	clock.Advance(100 * time.Millisecond)
+	if len(infos) != 3 || l.Time() != 300*time.Millisecond {
+		t.Errorf("%d ticks, Time %v after 100ms at double speed, want 3 and 300ms", len(infos), l.Time())
+	}
+}
+
+func TestRenderLoopDt(t *testing.T) {
+	clock := NewManualClock(time.Time{})
+	r := &RenderLoop{Clock: clock}
+	var dts []float32
+	r.Add(func() error {
+		dts = append(dts, r.Dt())
+		return nil
+	})
+
+	r.Render()
+	clock.Advance(50 * time.Millisecond)
+	r.Render()
+	if len(dts) != 2 || dts[0] != 0 || dts[1] != 0.05 {
+		t.Fatalf("dt %v, want [0 0.05]", dts)
+	}
+}
+
+// TestRenderLoopConcurrentRender is for the race detector, frames rendered from different goroutines must not race
+// on LastTime
+func TestRenderLoopConcurrentRender(t *testing.T) {
+	r := &RenderLoop{Clock: NewManualClock(time.Time{})}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 100 {
+			r.Render()
+		}
+	}()
+	for range 100 {
+		r.Render()
+	}
+	<-done
+}
@@ -0,0 +1,152 @@
+package notacore
+
+import (
+	"bytes"
+	"errors"
+	"expvar"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PublishExpvar exposes Stats as an expvar variable, served as JSON on /debug/vars by the default HTTP mux
+func (e *Engine) PublishExpvar(name string) error {
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar %q is already published", name)
+	}
+	expvar.Publish(name, expvar.Func(func() any { return e.Stats() }))
+	return nil
+}
+
+// MetricsHandler serves Stats in the Prometheus text format
+func (e *Engine) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write(PrometheusText(e.Stats()))
+	})
+}
+
+// ServeMetrics serves MetricsHandler on /metrics at 127.0.0.1:port until the returned server is shut down. Port 0
+// picks a free port, read it back from the server's Addr
+func (e *Engine) ServeMetrics(port int) (*http.Server, error) {
+	if port < 0 || port > 65535 {
+		return nil, errors.New("invalid metrics port")
+	}
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e.MetricsHandler())
+	srv := &http.Server{Addr: ln.Addr().String(), Handler: mux}
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	return srv, nil
+}
+
+// PrometheusText renders stats in the Prometheus text exposition format
+func PrometheusText(stats Stats) []byte {
+	p := promWriter{families: map[string]*bytes.Buffer{}}
+
+	for wi, ws := range stats.Windows {
+		window := []string{"window", strconv.Itoa(wi), "title", ws.Title}
+		p.summary("notabor_window_frame_seconds", "Time between frames of a window", window, ws.FrameTime)
+		p.summary("notabor_window_render_seconds", "Time spent rendering and swapping a frame", window, ws.RenderTime)
+		p.value("notabor_render_frames_total", "counter", "Frames drawn by a render loop", window, float64(ws.Render.Frames))
+		for _, rs := range ws.Render.Runnables {
+			labels := append(window[:4:4], "loop", "render", "runnable", rs.Name, "id", strconv.FormatUint(rs.ID, 10))
+			p.summary("notabor_runnable_seconds", "Time a runnable takes per call", labels, rs.Time)
+		}
+
+		for li, ls := range ws.Loops {
+			loop := append(window[:4:4], "loop", strconv.Itoa(li))
+			p.value("notabor_loop_ticks_total", "counter", "Ticks run by a logic loop", loop, float64(ls.Ticks))
+			p.value("notabor_loop_late_ticks_total", "counter", "Ticks started a full interval or more after they were due", loop, float64(ls.Late))
+			p.value("notabor_loop_dropped_ticks_total", "counter", "Ticks skipped because the loop fell too far behind", loop, float64(ls.Dropped))
+			p.value("notabor_loop_overruns_total", "counter", "Ticks that took longer than the interval between ticks", loop, float64(ls.Overruns))
+			p.value("notabor_loop_target_hz", "gauge", "Configured tick rate of a logic loop", loop, float64(ls.Hz))
+			p.value("notabor_loop_actual_hz", "gauge", "Measured tick rate of a logic loop", loop, ls.ActualHz)
+			p.value("notabor_loop_time_scale", "gauge", "Time scale of a logic loop", loop, float64(ls.TimeScale))
+			p.summary("notabor_loop_tick_seconds", "Time spent running a tick", loop, ls.TickTime)
+			p.summary("notabor_loop_jitter_seconds", "How long after they were due ticks started", loop, ls.Jitter)
+			for _, rs := range ls.Runnables {
+				labels := append(loop[:6:6], "runnable", rs.Name, "id", strconv.FormatUint(rs.ID, 10))
+				p.summary("notabor_runnable_seconds", "Time a runnable takes per call", labels, rs.Time)
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	for _, name := range p.order {
+		out.Write(p.families[name].Bytes())
+	}
+	return out.Bytes()
+}
+
+// promWriter groups samples by metric family, the format wants each family in one block
+type promWriter struct {
+	families map[string]*bytes.Buffer
+	order    []string
+}
+
+func (p *promWriter) family(name, kind, help string) *bytes.Buffer {
+	b, ok := p.families[name]
+	if !ok {
+		b = &bytes.Buffer{}
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		p.families[name] = b
+		p.order = append(p.order, name)
+	}
+	return b
+}
+
+func (p *promWriter) value(name, kind, help string, labels []string, v float64) {
+	b := p.family(name, kind, help)
+	fmt.Fprintf(b, "%s%s %s\n", name, promLabels(labels), strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+func (p *promWriter) summary(name, help string, labels []string, t TimingStats) {
+	b := p.family(name, "summary", help)
+	quantiles := []struct {
+		q string
+		d float64
+	}{
+		{"0", t.Min.Seconds()},
+		{"0.5", t.P50.Seconds()},
+		{"0.95", t.P95.Seconds()},
+		{"0.99", t.P99.Seconds()},
+		{"1", t.Max.Seconds()},
+	}
+	for _, q := range quantiles {
+		l := append(labels[:len(labels):len(labels)], "quantile", q.q)
+		fmt.Fprintf(b, "%s%s %s\n", name, promLabels(l), strconv.FormatFloat(q.d, 'g', -1, 64))
+	}
+	fmt.Fprintf(b, "%s_sum%s %s\n", name, promLabels(labels), strconv.FormatFloat(t.Total.Seconds(), 'g', -1, 64))
+	fmt.Fprintf(b, "%s_count%s %d\n", name, promLabels(labels), t.Count)
+}
+
+// promLabels formats key, value pairs as {key="value",...}
+func promLabels(kv []string) string {
+	if len(kv) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := 0; i+1 < len(kv); i += 2 {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(kv[i])
+		sb.WriteString(`="`)
+		sb.WriteString(promEscaper.Replace(kv[i+1]))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
@@ -0,0 +1,127 @@
+package notacore
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrDone is returned by a runnable that finished its work. The runnable is removed without being reported as a
+// failure, whatever the loop's ErrorPolicy
+var ErrDone = errors.New("runnable done")
+
+// ErrorPolicy decides what a loop does with a runnable that returned an error or panicked
+type ErrorPolicy int
+
+const (
+	DefaultErrorPolicy ErrorPolicy = iota // RemoveOnError for a FixedHzLoop, KeepOnError for a RenderLoop
+	RemoveOnError                         // remove the runnable and keep the loop going
+	KeepOnError                           // keep the runnable, it runs again next tick
+	StopLoopOnError                       // stop the loop after the failing runnable
+	StopEngineOnError                     // stop the engine, Engine.Run returns the error
+)
+
+// RunnableError is the error a loop reports for a failing runnable
+type RunnableError struct {
+	Err    error           // what the runnable returned, nil if it panicked
+	Panic  any             // the recovered value if the runnable panicked
+	Stack  []byte          // stack trace of the panic
+	Name   string          // name of the runnable, empty if it has none
+	Handle *RunnableHandle // nil for OneTimeRunnables
+	Info   TickInfo
+}
+
+func (e *RunnableError) Error() string {
+	name := "runnable"
+	if e.Name != "" {
+		name = fmt.Sprintf("runnable %q", e.Name)
+	}
+	if e.Panic != nil {
+		return fmt.Sprintf("%s panicked on tick %d: %v", name, e.Info.Tick, e.Panic)
+	}
+	return fmt.Sprintf("%s failed on tick %d: %v", name, e.Info.Tick, e.Err)
+}
+
+func (e *RunnableError) Unwrap() error { return e.Err }
+
+// callRunnable runs fn and recovers a panic so it cannot take the loop down
+func callRunnable(fn TickRunnable, info TickInfo) (panicked any, stack []byte, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			panicked = p
+			stack = debug.Stack()
+			if cp, ok := p.(coroutinePanic); ok {
+				panicked = cp.value
+				stack = cp.stack
+			}
+		}
+	}()
+	return nil, nil, fn(info)
+}
+
+// runnableFailure is shared by both loops to report a failing runnable and carry out the error policy
+type runnableFailure struct {
+	policy   ErrorPolicy
+	fallback ErrorPolicy
+	onError  func(err *RunnableError)
+	engine   *Engine
+}
+
+// run calls the runnable behind h (or fn when h is nil) and handles its error. It reports whether the loop has to
+// stop
+func (f runnableFailure) run(h *RunnableHandle, fn TickRunnable, info TickInfo) bool {
+	panicked, stack, err := callRunnable(fn, info)
+	if err == nil && panicked == nil {
+		return false
+	}
+	if panicked == nil && errors.Is(err, ErrDone) {
+		if h != nil {
+			h.Remove()
+		}
+		return false
+	}
+
+	rerr := &RunnableError{Err: err, Panic: panicked, Stack: stack, Handle: h, Info: info}
+	if h != nil {
+		rerr.Name = h.Name()
+	}
+	f.report(rerr)
+
+	policy := f.policy
+	if policy == DefaultErrorPolicy {
+		policy = f.fallback
+	}
+	switch policy {
+	case RemoveOnError:
+		if h != nil {
+			h.Remove()
+		}
+	case StopLoopOnError:
+		return true
+	case StopEngineOnError:
+		if f.engine == nil {
+			return true
+		}
+		f.engine.fail(rerr)
+	}
+	return false
+}
+
+// report hands rerr to the loop's and the engine's OnError, or prints it when there is neither
+func (f runnableFailure) report(rerr *RunnableError) {
+	reported := false
+	if f.onError != nil {
+		f.onError(rerr)
+		reported = true
+	}
+	if f.engine != nil && f.engine.OnError != nil {
+		f.engine.OnError(rerr)
+		reported = true
+	}
+	if !reported {
+		fmt.Println(rerr)
+		if rerr.Stack != nil {
+			fmt.Printf("%s\n", rerr.Stack)
+		}
+	}
+}
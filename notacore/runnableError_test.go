@@ -0,0 +1,105 @@
+package notacore
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTest = errors.New("test failure")
+
+func TestErrDoneRemovesQuietly(t *testing.T) {
+	reported := 0
+	l := &FixedHzLoop{ErrorPolicy: KeepOnError, OnError: func(*RunnableError) { reported++ }}
+	clock := manualLoop(t, l)
+
+	calls := 0
+	l.Add(func() error {
+		calls++
+		return ErrDone
+	})
+
+	clock.Advance(300 * time.Millisecond)
+	if calls != 1 || l.Len() != 0 || reported != 0 {
+		t.Errorf("%d calls, Len %d, %d reports, want 1, 0, 0", calls, l.Len(), reported)
+	}
+}
+
+func TestErrorPolicies(t *testing.T) {
+	cases := []struct {
+		policy    ErrorPolicy
+		calls     int // of the failing runnable over three ticks
+		neighbour int // of the runnable after it
+		ticks     uint64
+	}{
+		{DefaultErrorPolicy, 1, 3, 3},
+		{RemoveOnError, 1, 3, 3},
+		{KeepOnError, 3, 3, 3},
+		{StopLoopOnError, 1, 0, 1},
+		{StopEngineOnError, 1, 0, 1}, // without an engine it stops the loop
+	}
+	for _, c := range cases {
+		var reported []*RunnableError
+		l := &FixedHzLoop{ErrorPolicy: c.policy, OnError: func(err *RunnableError) { reported = append(reported, err) }}
+		clock := manualLoop(t, l)
+
+		calls, neighbour := 0, 0
+		l.Add(func() error {
+			calls++
+			return errTest
+		}).Named("failing")
+		l.Add(func() error {
+			neighbour++
+			return nil
+		})
+
+		clock.Advance(300 * time.Millisecond)
+		if calls != c.calls || neighbour != c.neighbour || l.Counters().Ticks != c.ticks {
+			t.Errorf("policy %d: %d calls, neighbour %d, %d ticks, want %d, %d, %d", c.policy, calls, neighbour,
+				l.Counters().Ticks, c.calls, c.neighbour, c.ticks)
+		}
+		if len(reported) != c.calls || !errors.Is(reported[0], errTest) || reported[0].Name != "failing" {
+			t.Errorf("policy %d: reported %v, want %d reports of the failing runnable", c.policy, reported, c.calls)
+		}
+	}
+}
+
+func TestStopEngineOnError(t *testing.T) {
+	e := &Engine{}
+	e.running.Store(true)
+	l := &FixedHzLoop{ErrorPolicy: StopEngineOnError, OnError: func(*RunnableError) {}}
+	clock := manualLoop(t, l)
+	l.engine = e
+
+	l.Add(func() error { return errTest })
+	clock.Advance(100 * time.Millisecond)
+
+	var rerr *RunnableError
+	if e.running.Load() || !errors.As(e.err, &rerr) || !errors.Is(rerr, errTest) {
+		t.Errorf("engine running %v with error %v, want stopped with the runnable's error", e.running.Load(), e.err)
+	}
+}
+
+func TestRunnablePanicIsRecovered(t *testing.T) {
+	var reported []*RunnableError
+	l := &FixedHzLoop{OnError: func(err *RunnableError) { reported = append(reported, err) }}
+	clock := manualLoop(t, l)
+
+	l.Add(func() error { panic("boom") }).Named("panicky")
+	after := 0
+	l.Add(func() error {
+		after++
+		return nil
+	})
+
+	clock.Advance(200 * time.Millisecond)
+	if len(reported) != 1 {
+		t.Fatalf("%d reports, want the panic once before the runnable is removed", len(reported))
+	}
+	if r := reported[0]; r.Panic != "boom" || r.Err != nil || len(r.Stack) == 0 || r.Name != "panicky" {
+		t.Errorf("got Panic %v, Err %v, %d bytes of stack, Name %q", r.Panic, r.Err, len(r.Stack), r.Name)
+	}
+	if after != 2 {
+		t.Errorf("the next runnable ran %d times, want 2", after)
+	}
+}
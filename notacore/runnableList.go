@@ -0,0 +1,246 @@
+package notacore
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrRunnableCycle is reported through OnError when Before and After constraints form a cycle, the runnables caught in
+// it keep their plain order
+var ErrRunnableCycle = errors.New("runnable ordering has a cycle")
+
+// RunnableHandle refers to a runnable added to a loop, use it to name, order or remove it. The setters return the
+// handle so they can be chained: loop.Add(fn).Named("physics").After("input")
+type RunnableHandle struct {
+	list *runnableList
+	fn   TickRunnable
+	seq  uint64
+
+	name   string
+	order  int
+	before []string
+	after  []string
+
+	removed      atomic.Bool
+	onRemove     func() // cleanup for runnables that hold resources, like coroutines
+	endsWithLoop bool   // removed when the loop stops, so its resources are not held by a stopped loop
+
+	timing timingWindow
+}
+
+// Named gives the runnable a name other runnables can be ordered against
+func (h *RunnableHandle) Named(name string) *RunnableHandle {
+	h.list.mu.Lock()
+	defer h.list.mu.Unlock()
+	h.name = name
+	h.list.sorted = nil
+	return h
+}
+
+// Order sets the runnable's priority, lower orders run first and equal orders run in the order they were added
+func (h *RunnableHandle) Order(order int) *RunnableHandle {
+	h.list.mu.Lock()
+	defer h.list.mu.Unlock()
+	h.order = order
+	h.list.sorted = nil
+	return h
+}
+
+// Before makes the runnable run before every runnable with one of the given names, regardless of Order
+func (h *RunnableHandle) Before(names ...string) *RunnableHandle {
+	h.list.mu.Lock()
+	defer h.list.mu.Unlock()
+	h.before = append(h.before, names...)
+	h.list.sorted = nil
+	return h
+}
+
+// After makes the runnable run after every runnable with one of the given names, regardless of Order
+func (h *RunnableHandle) After(names ...string) *RunnableHandle {
+	h.list.mu.Lock()
+	defer h.list.mu.Unlock()
+	h.after = append(h.after, names...)
+	h.list.sorted = nil
+	return h
+}
+
+func (h *RunnableHandle) Name() string {
+	h.list.mu.Lock()
+	defer h.list.mu.Unlock()
+	return h.name
+}
+
+// Remove takes the runnable out of its loop. It is safe to call at any time, including from inside a runnable of
+// the same loop: a removed runnable is never called again, even later in the current tick
+func (h *RunnableHandle) Remove() {
+	if h.removed.Swap(true) {
+		return
+	}
+	h.list.mu.Lock()
+	h.list.remove(h)
+	h.list.mu.Unlock()
+
+	if h.onRemove != nil {
+		h.onRemove()
+	}
+}
+
+func (h *RunnableHandle) Removed() bool { return h.removed.Load() }
+
+// runnableList keeps the runnables of a loop in run order
+type runnableList struct {
+	mu      sync.Mutex
+	handles []*RunnableHandle // in the order they were added
+	sorted  []*RunnableHandle // run order, nil when it has to be rebuilt
+	nextSeq uint64
+	cycle   error // found by the last sort and not reported yet
+}
+
+func (rl *runnableList) add(fn TickRunnable) *RunnableHandle {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	h := &RunnableHandle{list: rl, fn: fn, seq: rl.nextSeq}
+	rl.nextSeq++
+	rl.handles = append(rl.handles, h)
+	rl.sorted = nil
+	return h
+}
+
+// remove drops h from the list. rl.mu must be held
+func (rl *runnableList) remove(h *RunnableHandle) {
+	if i := slices.Index(rl.handles, h); i >= 0 {
+		rl.handles = slices.Delete(rl.handles, i, i+1)
+		rl.sorted = nil
+	}
+}
+
+// removeEndingWithLoop removes the runnables that end with their loop
+func (rl *runnableList) removeEndingWithLoop() {
+	rl.mu.Lock()
+	var ending []*RunnableHandle
+	for _, h := range rl.handles {
+		if h.endsWithLoop {
+			ending = append(ending, h)
+		}
+	}
+	rl.mu.Unlock()
+
+	for _, h := range ending {
+		h.Remove()
+	}
+}
+
+func (rl *runnableList) find(name string) *RunnableHandle {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	for _, h := range rl.handles {
+		if h.name == name {
+			return h
+		}
+	}
+	return nil
+}
+
+func (rl *runnableList) len() int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return len(rl.handles)
+}
+
+// snapshot returns the runnables in run order, the slice must not be modified
+func (rl *runnableList) snapshot() []*RunnableHandle {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if rl.sorted == nil {
+		rl.sorted = rl.sort()
+	}
+	return rl.sorted
+}
+
+// takeCycle returns the cycle found by the last sort once, so a loop reports it a single time
+func (rl *runnableList) takeCycle() error {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	err := rl.cycle
+	rl.cycle = nil
+	return err
+}
+
+// sort orders the handles by Order and insertion, then moves them as little as needed to satisfy Before and After.
+// Runnables caught in a cycle of constraints keep their plain order and the cycle is kept for takeCycle. rl.mu must
+// be held
+func (rl *runnableList) sort() []*RunnableHandle {
+	base := slices.Clone(rl.handles)
+	slices.SortStableFunc(base, func(a, b *RunnableHandle) int {
+		if c := cmp.Compare(a.order, b.order); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+
+	byName := map[string][]int{}
+	for i, h := range base {
+		if h.name != "" {
+			byName[h.name] = append(byName[h.name], i)
+		}
+	}
+
+	// edges[i] lists the runnables that have to wait for i
+	edges := make([][]int, len(base))
+	waits := make([]int, len(base))
+	link := func(first, then int) {
+		if first != then {
+			edges[first] = append(edges[first], then)
+			waits[then]++
+		}
+	}
+	for i, h := range base {
+		for _, name := range h.before {
+			for _, j := range byName[name] {
+				link(i, j)
+			}
+		}
+		for _, name := range h.after {
+			for _, j := range byName[name] {
+				link(j, i)
+			}
+		}
+	}
+
+	// Kahn's algorithm, always taking the earliest ready runnable in plain order
+	out := make([]*RunnableHandle, 0, len(base))
+	done := make([]bool, len(base))
+	for len(out) < len(base) {
+		next := -1
+		for i := range base {
+			if !done[i] && waits[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var names []string
+			for i, h := range base {
+				if !done[i] {
+					out = append(out, h)
+					if h.name != "" {
+						names = append(names, h.name)
+					}
+				}
+			}
+			rl.cycle = fmt.Errorf("%w, ignoring Before/After for %s", ErrRunnableCycle, strings.Join(names, ", "))
+			break
+		}
+		done[next] = true
+		out = append(out, base[next])
+		for _, j := range edges[next] {
+			waits[j]--
+		}
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package notacore
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRunnableOrder(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+
+	var ran []string
+	add := func(name string) *RunnableHandle {
+		return l.Add(func() error {
+			ran = append(ran, name)
+			return nil
+		}).Named(name)
+	}
+	add("render").Order(10)
+	add("physics").After("input")
+	add("input").Order(math.MinInt)
+	add("ai").Before("physics").Order(math.MaxInt)
+	add("audio")
+
+	clock.Advance(100 * time.Millisecond)
+	// Before and After move runnables as little as needed: ai keeps its late Order and holds physics back
+	want := []string{"input", "audio", "render", "ai", "physics"}
+	if !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+}
+
+func TestRunnableCycle(t *testing.T) {
+	var reported []error
+	l := &FixedHzLoop{OnError: func(err *RunnableError) { reported = append(reported, err) }}
+	clock := manualLoop(t, l)
+
+	var ran []string
+	add := func(name string) *RunnableHandle {
+		return l.Add(func() error {
+			ran = append(ran, name)
+			return nil
+		}).Named(name)
+	}
+	add("a").After("b")
+	add("b").After("a")
+	add("c").Order(-1)
+
+	clock.Advance(200 * time.Millisecond)
+	// the cycle falls back to the plain order and is reported once, not every tick
+	want := []string{"c", "a", "b", "c", "a", "b"}
+	if !slices.Equal(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], ErrRunnableCycle) {
+		t.Fatalf("reported %v, want one ErrRunnableCycle", reported)
+	}
+	if info := reported[0].(*RunnableError).Info; info.Tick != 0 {
+		t.Errorf("cycle reported on tick %d, want 0", info.Tick)
+	}
+}
+
+func TestRunnableRemoveDuringTick(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+
+	calls := 0
+	var later *RunnableHandle
+	l.Add(func() error {
+		later.Remove()
+		return nil
+	})
+	later = l.Add(func() error {
+		calls++
+		return nil
+	})
+
+	clock.Advance(100 * time.Millisecond)
+	if calls != 0 || l.Len() != 1 {
+		t.Errorf("removed runnable ran %d times, Len %d, want 0 and 1", calls, l.Len())
+	}
+}
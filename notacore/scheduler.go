@@ -0,0 +1,227 @@
+package notacore
+
+import (
+	"errors"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// timeEpsilon absorbs the rounding of loop time so a timer due exactly on a tick fires on that tick
+const timeEpsilon = time.Microsecond
+
+func reached(elapsed, due time.Duration) bool {
+	return elapsed+timeEpsilon >= due
+}
+
+// After runs fn once, d of loop time from now. Loop time is scaled and stops while paused, so timers follow
+// the loop's time scale. Remove the handle to cancel the timer
+func (l *FixedHzLoop) After(d time.Duration, fn Runnable) *RunnableHandle {
+	return l.Repeat(1, d, fn)
+}
+
+// Every runs fn every d of loop time, starting d from now, until the handle is removed or fn returns an error
+// (ErrDone to stop quietly)
+func (l *FixedHzLoop) Every(d time.Duration, fn Runnable) *RunnableHandle {
+	return l.Repeat(-1, d, fn)
+}
+
+// Repeat runs fn n times, every d of loop time starting d from now. A negative n repeats forever. When a tick
+// spans several periods, fn runs once for each of them so the count stays exact
+func (l *FixedHzLoop) Repeat(n int, d time.Duration, fn Runnable) *RunnableHandle {
+	due := l.Time() + d
+	left := n
+	return l.AddTick(func(info TickInfo) error {
+		for left != 0 && reached(info.Elapsed, due) {
+			if err := fn(); err != nil {
+				return err
+			}
+			if left > 0 {
+				left--
+			}
+			due += d
+			if d <= 0 {
+				break
+			}
+		}
+		if left == 0 {
+			return ErrDone
+		}
+		return nil
+	})
+}
+
+// ErrCoroutineStopped is returned by the wait calls of a coroutine whose handle was removed or whose loop stopped.
+// The coroutine should return, waiting again ends its goroutine on the spot
+var ErrCoroutineStopped = errors.New("coroutine stopped")
+
+// Coroutine lets a function spread its work across ticks: every Wait hands control back to the loop and picks up
+// again on a later tick. The function runs on its own goroutine but never at the same time as the loop's other
+// runnables, so it can touch the same state without locking
+type Coroutine struct {
+	resume chan TickInfo
+	yield  chan struct{}
+	exited chan struct{}
+	info   TickInfo
+
+	mu        sync.Mutex
+	started   bool
+	running   bool // the loop is waiting for the coroutine to yield
+	cancelled bool
+
+	stopped  bool // a wait returned ErrCoroutineStopped, the loop is not waiting for us
+	done     bool
+	err      error
+	panicked any
+	stack    []byte
+}
+
+// coroutinePanic carries a panic of the coroutine's goroutine over to the loop, with the original stack
+type coroutinePanic struct {
+	value any
+	stack []byte
+}
+
+// StartCoroutine runs fn as a coroutine, starting on the next tick. Returning nil finishes it quietly, an error
+// goes through the loop's ErrorPolicy. Removing the handle or stopping the loop makes its current or next wait
+// return ErrCoroutineStopped
+func (l *FixedHzLoop) StartCoroutine(fn func(co *Coroutine) error) *RunnableHandle {
+	co := &Coroutine{
+		resume: make(chan TickInfo),
+		yield:  make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+	h := l.AddTick(func(info TickInfo) error {
+		co.mu.Lock()
+		if co.cancelled {
+			co.mu.Unlock()
+			return ErrDone
+		}
+		if !co.started {
+			co.started = true
+			go co.run(fn)
+		}
+		co.running = true
+		co.mu.Unlock()
+
+		co.resume <- info
+		<-co.yield
+
+		co.mu.Lock()
+		co.running = false
+		cancelled := co.cancelled
+		co.mu.Unlock()
+
+		if !co.done {
+			if cancelled {
+				// removed while it ran, stop the wait it is in now
+				close(co.resume)
+				<-co.exited
+			}
+			return nil
+		}
+		if co.panicked != nil {
+			panic(coroutinePanic{value: co.panicked, stack: co.stack})
+		}
+		if co.err != nil {
+			return co.err
+		}
+		return ErrDone
+	})
+	h.endsWithLoop = true
+	h.onRemove = func() {
+		co.mu.Lock()
+		co.cancelled = true
+		// a running coroutine is released by the loop once it yields
+		release := co.started && !co.running && !co.done
+		co.mu.Unlock()
+
+		// the coroutine returns, deferred calls included, before Remove does
+		if release {
+			close(co.resume)
+			<-co.exited
+		}
+	}
+	return h
+}
+
+func (co *Coroutine) run(fn func(co *Coroutine) error) {
+	defer close(co.exited)
+	defer func() {
+		if p := recover(); p != nil {
+			co.panicked = p
+			co.stack = debug.Stack()
+		}
+		if co.stopped {
+			return
+		}
+		co.done = true
+		co.yield <- struct{}{}
+	}()
+
+	co.info = <-co.resume
+	co.err = fn(co)
+}
+
+// Info describes the tick the coroutine is currently running in
+func (co *Coroutine) Info() TickInfo { return co.info }
+
+// Yield hands control back to the loop and continues on the next tick. It returns ErrCoroutineStopped when the
+// coroutine was stopped instead
+func (co *Coroutine) Yield() error {
+	if co.stopped {
+		// the stop was ignored, nobody will resume us again
+		runtime.Goexit()
+	}
+	co.yield <- struct{}{}
+	info, ok := <-co.resume
+	if !ok {
+		co.stopped = true
+		return ErrCoroutineStopped
+	}
+	co.info = info
+	return nil
+}
+
+// Wait continues once d of loop time has passed
+func (co *Coroutine) Wait(d time.Duration) error {
+	due := co.info.Elapsed + d
+	if err := co.Yield(); err != nil {
+		return err
+	}
+	for !reached(co.info.Elapsed, due) {
+		if err := co.Yield(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WaitTicks continues n ticks later
+func (co *Coroutine) WaitTicks(n int) error {
+	for range n {
+		if err := co.Yield(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WaitUntil yields until cond reports true, it returns at once if it already does
+func (co *Coroutine) WaitUntil(cond func() bool) error {
+	for !cond() {
+		if err := co.Yield(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cancelled reports whether the coroutine was stopped by removing its handle or stopping its loop. The coroutine
+// returns, deferred calls included, before Remove or Stop does
+func (co *Coroutine) Cancelled() bool {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	return co.cancelled
+}
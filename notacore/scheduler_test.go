@@ -0,0 +1,159 @@
+package notacore
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// tickOf records the tick a timer fired on
+func tickOf(l *FixedHzLoop, fired *[]uint64) Runnable {
+	return func() error {
+		*fired = append(*fired, l.Counters().Ticks-1)
+		return nil
+	}
+}
+
+func TestAfterEveryRepeat(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+
+	var after, every, repeat, fast []uint64
+	ah := l.After(250*time.Millisecond, tickOf(l, &after))
+	l.Every(200*time.Millisecond, tickOf(l, &every))
+	rh := l.Repeat(3, 100*time.Millisecond, tickOf(l, &repeat))
+	l.Every(50*time.Millisecond, tickOf(l, &fast))
+
+	clock.Advance(time.Second)
+	// tick n ends at (n + 1) * 100ms of loop time
+	checks := []struct {
+		name      string
+		got, want []uint64
+	}{
+		{"After(250ms)", after, []uint64{2}},
+		{"Every(200ms)", every, []uint64{1, 3, 5, 7, 9}},
+		{"Repeat(3, 100ms)", repeat, []uint64{0, 1, 2}},
+		{"Every(50ms)", fast, []uint64{0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 6, 7, 7, 8, 8, 9, 9}},
+	}
+	for _, c := range checks {
+		if !slices.Equal(c.got, c.want) {
+			t.Errorf("%s fired on ticks %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if !ah.Removed() || !rh.Removed() {
+		t.Error("finished timers were not removed")
+	}
+}
+
+func TestTimersFollowTimeScale(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+
+	fired := false
+	l.After(200*time.Millisecond, func() error {
+		fired = true
+		return nil
+	})
+	l.SetTimeScale(0.5)
+	clock.Advance(300 * time.Millisecond)
+	if fired {
+		t.Fatal("After(200ms) fired after 150ms of loop time")
+	}
+	clock.Advance(100 * time.Millisecond)
+	if !fired {
+		t.Error("After(200ms) did not fire after 200ms of loop time")
+	}
+}
+
+func TestCoroutineWaits(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+
+	var ticks []uint64
+	h := l.StartCoroutine(func(co *Coroutine) error {
+		ticks = append(ticks, co.Info().Tick)
+		if err := co.WaitTicks(2); err != nil {
+			return err
+		}
+		ticks = append(ticks, co.Info().Tick)
+		if err := co.Wait(300 * time.Millisecond); err != nil {
+			return err
+		}
+		ticks = append(ticks, co.Info().Tick)
+		return nil
+	})
+
+	clock.Advance(time.Second)
+	if want := []uint64{0, 2, 5}; !slices.Equal(ticks, want) {
+		t.Errorf("coroutine ran on ticks %v, want %v", ticks, want)
+	}
+	if !h.Removed() {
+		t.Error("finished coroutine was not removed")
+	}
+}
+
+func TestCoroutineError(t *testing.T) {
+	var reported []*RunnableError
+	l := &FixedHzLoop{OnError: func(err *RunnableError) { reported = append(reported, err) }}
+	clock := manualLoop(t, l)
+
+	l.StartCoroutine(func(co *Coroutine) error {
+		co.Yield()
+		return errTest
+	})
+	l.StartCoroutine(func(co *Coroutine) error {
+		panic("boom")
+	})
+
+	clock.Advance(300 * time.Millisecond)
+	if len(reported) != 2 {
+		t.Fatalf("%d reports, want 2", len(reported))
+	}
+	if reported[0].Panic != "boom" || !errors.Is(reported[1], errTest) {
+		t.Errorf("reported %v, want the panic on the first tick and the error on the second", reported)
+	}
+}
+
+// stoppingCoroutine starts a coroutine that waits forever and records how its wait ended
+func stoppingCoroutine(l *FixedHzLoop, got *error, returned *bool) *RunnableHandle {
+	return l.StartCoroutine(func(co *Coroutine) error {
+		defer func() { *returned = true }()
+		for {
+			if err := co.Yield(); err != nil {
+				*got = err
+				return nil
+			}
+		}
+	})
+}
+
+func TestCoroutineStopsOnRemove(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+
+	var got error
+	returned := false
+	h := stoppingCoroutine(l, &got, &returned)
+	clock.Advance(200 * time.Millisecond)
+
+	h.Remove()
+	if !returned || !errors.Is(got, ErrCoroutineStopped) {
+		t.Errorf("after Remove: returned %v with %v, want returned with ErrCoroutineStopped", returned, got)
+	}
+}
+
+func TestCoroutineStopsWithLoop(t *testing.T) {
+	l := &FixedHzLoop{}
+	clock := manualLoop(t, l)
+
+	var got error
+	returned := false
+	h := stoppingCoroutine(l, &got, &returned)
+	clock.Advance(200 * time.Millisecond)
+
+	l.Stop()
+	if !returned || !errors.Is(got, ErrCoroutineStopped) || !h.Removed() {
+		t.Errorf("after Stop: returned %v with %v, removed %v, want all stopped", returned, got, h.Removed())
+	}
+}
@@ -0,0 +1,220 @@
+package notacore
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// statsWindow is how many recent samples the percentiles are computed from
+const statsWindow = 256
+
+// TimingStats summarizes a series of durations. Count and Total cover every sample ever recorded, the rest only
+// the most recent ones
+type TimingStats struct {
+	Count uint64
+	Total time.Duration
+
+	Min time.Duration
+	Avg time.Duration
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// RunnableStats is how long a runnable takes per call
+type RunnableStats struct {
+	ID   uint64 // unique within the loop, in the order runnables were added
+	Name string
+	Time TimingStats
+}
+
+// LoopStats describes how a FixedHzLoop is keeping up
+type LoopStats struct {
+	Hz        float32
+	ActualHz  float64 // ticks per wall second over the recent ticks
+	TimeScale float32
+	Paused    bool
+
+	Ticks    uint64
+	Late     uint64
+	Dropped  uint64
+	Overruns uint64 // ticks that took longer than the interval between ticks
+
+	TickTime TimingStats // time spent running a tick
+	Jitter   TimingStats // how long after they were due ticks started
+
+	Runnables []RunnableStats
+}
+
+// RenderLoopStats describes the runnables of a RenderLoop
+type RenderLoopStats struct {
+	Frames    uint64
+	Paused    bool
+	Runnables []RunnableStats
+}
+
+// WindowStats describes the frames of a window and all its loops
+type WindowStats struct {
+	Title      string
+	FrameTime  TimingStats // time between frames
+	RenderTime TimingStats // time spent rendering and swapping a frame
+	Render     RenderLoopStats
+	Loops      []LoopStats
+}
+
+// Stats is a snapshot of the engine's timings
+type Stats struct {
+	Windows []WindowStats
+}
+
+// timingWindow records durations, the zero value is ready to use
+type timingWindow struct {
+	mu      sync.Mutex
+	samples []time.Duration // ring of the most recent samples
+	next    int
+	count   uint64
+	total   time.Duration
+}
+
+func (w *timingWindow) add(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.samples) < statsWindow {
+		w.samples = append(w.samples, d)
+	} else {
+		w.samples[w.next] = d
+		w.next = (w.next + 1) % statsWindow
+	}
+	w.count++
+	w.total += d
+}
+
+func (w *timingWindow) stats() TimingStats {
+	w.mu.Lock()
+	sorted := slices.Clone(w.samples)
+	s := TimingStats{Count: w.count, Total: w.total}
+	w.mu.Unlock()
+
+	if len(sorted) == 0 {
+		return s
+	}
+	slices.Sort(sorted)
+
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+	s.Min = sorted[0]
+	s.Max = sorted[len(sorted)-1]
+	s.Avg = sum / time.Duration(len(sorted))
+	s.P50 = percentile(sorted, 0.50)
+	s.P95 = percentile(sorted, 0.95)
+	s.P99 = percentile(sorted, 0.99)
+	return s
+}
+
+// percentile picks the nearest-rank percentile q of sorted
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	i := int(q*float64(len(sorted))+0.999999) - 1
+	return sorted[min(max(i, 0), len(sorted)-1)]
+}
+
+// rateWindow measures how often something happens over its most recent occurrences
+type rateWindow struct {
+	mu    sync.Mutex
+	times []time.Time
+	next  int
+}
+
+func (w *rateWindow) add(t time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.times) < statsWindow {
+		w.times = append(w.times, t)
+	} else {
+		w.times[w.next] = t
+		w.next = (w.next + 1) % statsWindow
+	}
+}
+
+// perSecond returns the rate over the recorded span, 0 with fewer than two occurrences
+func (w *rateWindow) perSecond() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n := len(w.times)
+	if n < 2 {
+		return 0
+	}
+	oldest := w.times[w.next%n]
+	newest := w.times[(w.next-1+n)%n]
+	span := newest.Sub(oldest).Seconds()
+	if span <= 0 {
+		return 0
+	}
+	return float64(n-1) / span
+}
+
+// runnableStats snapshots the timings of a loop's runnables in run order
+func (rl *runnableList) runnableStats() []RunnableStats {
+	handles := rl.snapshot()
+	out := make([]RunnableStats, 0, len(handles))
+	for _, h := range handles {
+		out = append(out, RunnableStats{ID: h.seq, Name: h.Name(), Time: h.timing.stats()})
+	}
+	return out
+}
+
+// Stats returns the loop's counters and timings
+func (l *FixedHzLoop) Stats() LoopStats {
+	l.mu.Lock()
+	s := LoopStats{
+		Hz:        l.Hz,
+		TimeScale: l.scale(),
+		Paused:    l.paused,
+		Ticks:     l.ticks,
+		Late:      l.late,
+		Dropped:   l.dropped,
+		Overruns:  l.overruns,
+	}
+	l.mu.Unlock()
+
+	s.ActualHz = l.tickRate.perSecond()
+	s.TickTime = l.tickTime.stats()
+	s.Jitter = l.jitter.stats()
+	s.Runnables = l.runnables.runnableStats()
+	return s
+}
+
+// Stats returns the timings of the render loop's runnables
+func (r *RenderLoop) Stats() RenderLoopStats {
+	r.mu.Lock()
+	s := RenderLoopStats{Frames: r.frames, Paused: r.paused}
+	r.mu.Unlock()
+
+	s.Runnables = r.runnables.runnableStats()
+	return s
+}
+
+// Stats collects the timings of every window and loop of the engine
+func (e *Engine) Stats() Stats {
+	var s Stats
+	for _, w := range e.Windows {
+		cfg := w.GetConfig()
+		rt := w.GetRuntime()
+		ws := WindowStats{
+			Title:      cfg.Title,
+			FrameTime:  rt.frameTime.stats(),
+			RenderTime: rt.renderTime.stats(),
+		}
+		if cfg.RenderLoop != nil {
+			ws.Render = cfg.RenderLoop.Stats()
+		}
+		for _, loop := range cfg.LogicLoops {
+			ws.Loops = append(ws.Loops, loop.Stats())
+		}
+		s.Windows = append(s.Windows, ws)
+	}
+	return s
+}
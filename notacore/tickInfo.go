@@ -0,0 +1,31 @@
+package notacore
+
+import "time"
+
+// TickInfo describes the tick or frame a TickRunnable is called for
+type TickInfo struct {
+	Tick uint64 // index of this tick or frame, starting at 0
+
+	Dt         float32 // scaled seconds this tick stands for
+	UnscaledDt float32 // wall seconds this tick stands for
+
+	Elapsed         time.Duration // scaled time up to and including this tick
+	UnscaledElapsed time.Duration // wall time since the loop started
+
+	// Alpha is the interpolation factor of the window's first logic loop on render frames, 0 on logic ticks
+	Alpha float32
+
+	Loop       *FixedHzLoop // the logic loop running the tick, nil on render frames
+	RenderLoop *RenderLoop  // the render loop drawing the frame, nil on logic ticks
+	Window     Window       // the window owning the loop, nil until the engine runs it
+}
+
+// TickRunnable is a Runnable that is told about the tick it runs in
+type TickRunnable func(info TickInfo) error
+
+// WithInfo adapts a plain Runnable so it can be used wherever a TickRunnable is expected
+func (r Runnable) WithInfo() TickRunnable {
+	return func(TickInfo) error {
+		return r()
+	}
+}
@@ -0,0 +1,163 @@
+package notacore
+
+import (
+	"NotaborEngine/notagl"
+	"NotaborEngine/notashader"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// VirtualWindow is a Window without a display, used by a headless engine. Its render loop runs on schedule and its
+// renderers accept submissions like the real ones, but nothing is drawn. Shaders are tracked by name only and
+// textures are loaded without being uploaded, so Bind panics on them
+type VirtualWindow struct {
+	ID         int
+	Config     WindowConfig
+	RunTime    WindowBaseRuntime
+	Renderer2D *notagl.Renderer2D
+	Renderer3D *notagl.Renderer3D
+	TextureMgr *notagl.TextureManager
+	Shaders    map[string]uint32
+
+	closed atomic.Bool
+}
+
+func (w *VirtualWindow) GetConfig() *WindowConfig       { return &w.Config }
+func (w *VirtualWindow) GetRuntime() *WindowBaseRuntime { return &w.RunTime }
+func (w *VirtualWindow) MakeContextCurrent()            {}
+func (w *VirtualWindow) SwapBuffers()                   {}
+func (w *VirtualWindow) ShouldClose() bool              { return w.closed.Load() }
+func (w *VirtualWindow) Close()                         { w.closed.Store(true) }
+func (w *VirtualWindow) Size() (int, int)               { return w.Config.W, w.Config.H }
+func (w *VirtualWindow) Position() (int, int)           { return w.Config.X, w.Config.Y }
+
+// RunRenderer runs the render loop and throws away what was submitted to the renderers
+func (w *VirtualWindow) RunRenderer() {
+	w.Renderer2D.Reset()
+	w.Renderer3D.Reset()
+	if w.Config.RenderLoop != nil {
+		w.Config.RenderLoop.Render()
+	}
+}
+
+func (w *VirtualWindow) SetWindowType(t WindowType) error {
+	w.Config.Type = t
+	return nil
+}
+
+func (w *VirtualWindow) CreateShader(shader notashader.Shader) error {
+	if w.Shaders == nil {
+		w.Shaders = make(map[string]uint32)
+	}
+	if _, found := w.Shaders[shader.Name]; found {
+		return errors.New("shader with name " + shader.Name + " already exists")
+	}
+	w.Shaders[shader.Name] = 0
+	return nil
+}
+
+func (w *VirtualWindow) GetShader(name string) (uint32, error) {
+	value, found := w.Shaders[name]
+	if !found {
+		return 0, errors.New("shader with name " + name + " is not found")
+	}
+	return value, nil
+}
+
+func (w *VirtualWindow) UpdateShader(shader notashader.Shader) error {
+	_, err := w.GetShader(shader.Name)
+	return err
+}
+
+func (w *VirtualWindow) DeleteShader(name string) uint32 {
+	program := w.Shaders[name]
+	delete(w.Shaders, name)
+	return program
+}
+
+func (w *VirtualWindow) UseShader(name string) error {
+	_, err := w.GetShader(name)
+	return err
+}
+
+// LoadTexture reads the image so its size and pixels are available, no OpenGL texture is created
+func (w *VirtualWindow) LoadTexture(name, path string) (*notagl.Texture, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	tex, err := w.TextureMgr.Load(name, absPath, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load texture: %w", err)
+	}
+	return tex, nil
+}
+
+func (w *VirtualWindow) GetTexture(name string) (*notagl.Texture, error) {
+	return w.TextureMgr.Get(name)
+}
+
+func (w *VirtualWindow) UnloadTexture(name string) error {
+	return w.TextureMgr.Unload(name)
+}
+
+// InitHeadless prepares the engine to run without a display, use it instead of InitPlatform. GLFW and OpenGL are
+// never touched, windows are created with CreateVirtualWindow and there is no real input. Build with the headless tag
+// to leave GLFW and OpenGL out of the binary altogether
+func (e *Engine) InitHeadless() {
+	e.headless = true
+	e.Input = &InputManager{}
+}
+
+// Headless reports whether the engine was set up with InitHeadless
+func (e *Engine) Headless() bool { return e.headless }
+
+// CreateVirtualWindow creates a window for a headless engine
+func (e *Engine) CreateVirtualWindow(cfg WindowConfig) (*VirtualWindow, error) {
+	if !e.headless {
+		return nil, errors.New("virtual windows need a headless engine, call InitHeadless first")
+	}
+	if cfg.W <= 0 || cfg.H <= 0 {
+		return nil, errors.New("invalid window size")
+	}
+	if cfg.RenderLoop == nil || cfg.RenderLoop.MaxHz <= 0 {
+		return nil, errors.New("window needs a render loop with a positive MaxHz")
+	}
+
+	win := &VirtualWindow{
+		ID:     len(e.Windows),
+		Config: cfg,
+		RunTime: WindowBaseRuntime{
+			lastRender: e.now(),
+			targetDt:   time.Duration(float64(time.Second) / float64(cfg.RenderLoop.MaxHz)),
+		},
+		Renderer2D: &notagl.Renderer2D{},
+		Renderer3D: &notagl.Renderer3D{},
+		TextureMgr: notagl.NewTextureManager(),
+	}
+	e.Windows = append(e.Windows, win)
+	return win, nil
+}
+
+// StopAfterTicks stops the engine once loop has run n ticks. The loop is paused on its last tick so it runs
+// exactly n of them
+func (e *Engine) StopAfterTicks(loop *FixedHzLoop, n uint64) *RunnableHandle {
+	return loop.AddTick(func(info TickInfo) error {
+		if info.Tick+1 < n {
+			return nil
+		}
+		loop.Pause()
+		e.Stop()
+		return ErrDone
+	}).Order(math.MaxInt)
+}
+
+// StopWhen stops the engine once cond reports true, it is checked on the main thread before every frame
+func (e *Engine) StopWhen(cond func() bool) {
+	e.stopConds = append(e.stopConds, cond)
+}
@@ -1,11 +1,6 @@
 package notacore
 
-import (
-	"errors"
-
-	"github.com/go-gl/gl/v4.6-core/gl"
-	"github.com/go-gl/glfw/v3.3/glfw"
-)
+import "NotaborEngine/notamath"
 
 type WindowType int
 
@@ -15,50 +10,11 @@ const (
 	Borderless
 )
 
-func (w *GlfwWindow2D) SetWindowType(t WindowType) error {
-	return setWindowType(w.Config, w.Handle, t)
-}
-
-func (w *GlfwWindow3D) SetWindowType(t WindowType) error {
-	return setWindowType(w.Config, w.Handle, t)
-}
-
-func setWindowType(config WindowConfig, handle *glfw.Window, t WindowType) error {
-	switch t {
-	case Fullscreen:
-		monitor := glfw.GetPrimaryMonitor()
-		if monitor == nil {
-			return errors.New("no primary monitor found")
-		}
-
-		videoMode := monitor.GetVideoMode()
-		if videoMode == nil {
-			return errors.New("could not get video mode")
-		}
-		handle.SetMonitor(monitor, 0, 0, videoMode.Width, videoMode.Height, videoMode.RefreshRate)
-		updateViewport(videoMode.Width, videoMode.Height, config.W, config.H)
-		config.Type = Fullscreen
-	case Borderless:
-		handle.SetAttrib(glfw.Decorated, glfw.False)
-		config.Type = Borderless
-	case Windowed:
-		handle.SetMonitor(nil, config.X, config.Y, config.W, config.H, glfw.DontCare)
-		handle.SetAttrib(glfw.Decorated, glfw.True)
-		updateViewport(config.W, config.H, config.W, config.H)
-		config.Type = Windowed
-	default:
-		return errors.New("invalid window type")
-	}
-	return nil
-}
-
-func updateViewport(winW, winH, targetW, targetH int) {
+// letterbox fits the target aspect ratio into the window, the origin is at the bottom left like OpenGL's
+func letterbox(winW, winH, targetW, targetH int) (viewX, viewY, viewW, viewH int32) {
 	targetAspect := float32(targetW) / float32(targetH)
 	windowAspect := float32(winW) / float32(winH)
 
-	var viewW, viewH int32
-	var viewX, viewY int32
-
 	if windowAspect > targetAspect {
 		// Window is wider than target aspect ratio
 		viewH = int32(winH)
@@ -73,8 +29,19 @@ func updateViewport(winW, winH, targetW, targetH int) {
 		viewY = (int32(winH) - viewH) / 2
 	}
 
-	gl.Viewport(viewX, viewY, viewW, viewH)
-	// Scissor ensures that glClear only clears the viewport area if needed,
-	gl.Enable(gl.SCISSOR_TEST)
-	gl.Scissor(viewX, viewY, viewW, viewH)
+	return viewX, viewY, viewW, viewH
+}
+
+// cameraViewport returns the letterboxed area in window pixels with the origin at the top left, as Camera2D expects
+func cameraViewport(winW, winH, targetW, targetH int) notamath.Rect {
+	if winW <= 0 || winH <= 0 || targetW <= 0 || targetH <= 0 {
+		return notamath.Rect{}
+	}
+
+	x, y, w, h := letterbox(winW, winH, targetW, targetH)
+	top := float32(int32(winH) - y - h)
+	return notamath.Rect{
+		Min: notamath.Po2{X: float32(x), Y: top},
+		Max: notamath.Po2{X: float32(x + w), Y: top + float32(h)},
+	}
 }
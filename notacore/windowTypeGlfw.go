@@ -0,0 +1,56 @@
+//go:build !headless
+
+package notacore
+
+import (
+	"errors"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func (w *GlfwWindow2D) SetWindowType(t WindowType) error {
+	return setWindowType(w.Config, w.Handle, t)
+}
+
+func (w *GlfwWindow3D) SetWindowType(t WindowType) error {
+	return setWindowType(w.Config, w.Handle, t)
+}
+
+func setWindowType(config WindowConfig, handle *glfw.Window, t WindowType) error {
+	switch t {
+	case Fullscreen:
+		monitor := glfw.GetPrimaryMonitor()
+		if monitor == nil {
+			return errors.New("no primary monitor found")
+		}
+
+		videoMode := monitor.GetVideoMode()
+		if videoMode == nil {
+			return errors.New("could not get video mode")
+		}
+		handle.SetMonitor(monitor, 0, 0, videoMode.Width, videoMode.Height, videoMode.RefreshRate)
+		updateViewport(videoMode.Width, videoMode.Height, config.W, config.H)
+		config.Type = Fullscreen
+	case Borderless:
+		handle.SetAttrib(glfw.Decorated, glfw.False)
+		config.Type = Borderless
+	case Windowed:
+		handle.SetMonitor(nil, config.X, config.Y, config.W, config.H, glfw.DontCare)
+		handle.SetAttrib(glfw.Decorated, glfw.True)
+		updateViewport(config.W, config.H, config.W, config.H)
+		config.Type = Windowed
+	default:
+		return errors.New("invalid window type")
+	}
+	return nil
+}
+
+func updateViewport(winW, winH, targetW, targetH int) {
+	viewX, viewY, viewW, viewH := letterbox(winW, winH, targetW, targetH)
+
+	gl.Viewport(viewX, viewY, viewW, viewH)
+	// Scissor ensures that glClear only clears the viewport area if needed,
+	gl.Enable(gl.SCISSOR_TEST)
+	gl.Scissor(viewX, viewY, viewW, viewH)
+}
@@ -0,0 +1,190 @@
+package notagl
+
+import (
+	"NotaborEngine/notamath"
+	"math"
+)
+
+// Camera2D maps world units to the screen. Set it as Renderer2D.Camera and every submitted polygon is drawn
+// through it, the window keeps Viewport in sync with its size
+type Camera2D struct {
+	Position notamath.Vec2
+	Rotation float32 // radians
+	Zoom     float32 // 1 shows WorldHeight units vertically, 2 shows half as many
+
+	// Viewport is the area of the window the camera draws to in pixels, origin at the top left like cursor positions
+	Viewport notamath.Rect
+
+	// WorldHeight is how many world units fit vertically at zoom 1, the width follows the viewport's aspect
+	WorldHeight float32
+
+	// Deadzone is the half size of the box around the camera the follow target can move in freely
+	Deadzone notamath.Vec2
+	// FollowSpeed smooths Follow, higher is snappier and 0 snaps instantly
+	FollowSpeed float32
+
+	// Bounds keeps the visible area inside this rect when set
+	Bounds *notamath.Rect
+
+	shakeIntensity float32
+	shakeDuration  float32
+	shakeTime      float32
+	shakeOffset    notamath.Vec2
+	shakeNoise     *notamath.Simplex
+}
+
+// NewCamera2D returns a camera showing worldHeight units vertically over a viewport of width x height pixels
+func NewCamera2D(width, height int, worldHeight float32) *Camera2D {
+	return &Camera2D{
+		Zoom:        1,
+		Viewport:    notamath.Rect{Max: notamath.Po2{X: float32(width), Y: float32(height)}},
+		WorldHeight: worldHeight,
+		shakeNoise:  notamath.NewSimplex(1),
+	}
+}
+
+// ViewSize returns the width and height of the visible world area, ignoring rotation
+func (c *Camera2D) ViewSize() notamath.Vec2 {
+	h := c.WorldHeight
+	if h <= 0 {
+		h = 2
+	}
+	zoom := c.Zoom
+	if zoom <= 0 {
+		zoom = 1
+	}
+
+	aspect := float32(1)
+	if vh := c.Viewport.Height(); vh > 0 {
+		aspect = c.Viewport.Width() / vh
+	}
+	return notamath.Vec2{X: h * aspect / zoom, Y: h / zoom}
+}
+
+// View returns the matrix taking world positions into camera space, shake included
+func (c *Camera2D) View() notamath.Mat3 {
+	pos := c.Position.Add(c.shakeOffset)
+	return notamath.Mat3Rotation(-c.Rotation).Mul(notamath.Mat3Translation(pos.Neg()))
+}
+
+// ViewProjection returns the matrix taking world positions to normalized device coordinates
+func (c *Camera2D) ViewProjection() notamath.Mat3 {
+	size := c.ViewSize()
+	return notamath.Mat3Scale(notamath.Vec2{X: 2 / size.X, Y: 2 / size.Y}).Mul(c.View())
+}
+
+// ScreenToWorld converts a position in window pixels, such as the cursor, to world units
+func (c *Camera2D) ScreenToWorld(screen notamath.Po2) notamath.Po2 {
+	return c.ViewProjection().InverseAffine().TransformPo2(c.screenToNDC(screen))
+}
+
+// WorldToScreen converts a world position to window pixels
+func (c *Camera2D) WorldToScreen(world notamath.Po2) notamath.Po2 {
+	ndc := c.ViewProjection().TransformPo2(world)
+	return notamath.Po2{
+		X: c.Viewport.Min.X + (ndc.X+1)/2*c.Viewport.Width(),
+		Y: c.Viewport.Min.Y + (1-ndc.Y)/2*c.Viewport.Height(),
+	}
+}
+
+func (c *Camera2D) screenToNDC(screen notamath.Po2) notamath.Po2 {
+	w, h := c.Viewport.Width(), c.Viewport.Height()
+	if w <= 0 || h <= 0 {
+		return notamath.Po2{}
+	}
+	return notamath.Po2{
+		X: (screen.X-c.Viewport.Min.X)/w*2 - 1,
+		Y: 1 - (screen.Y-c.Viewport.Min.Y)/h*2,
+	}
+}
+
+// Follow moves the camera just enough to keep target inside the deadzone, easing by FollowSpeed over dt seconds
+func (c *Camera2D) Follow(target notamath.Po2, dt float32) {
+	desired := c.Position
+	offset := notamath.Vec2(target).Sub(c.Position)
+
+	if offset.X > c.Deadzone.X {
+		desired.X = target.X - c.Deadzone.X
+	} else if offset.X < -c.Deadzone.X {
+		desired.X = target.X + c.Deadzone.X
+	}
+	if offset.Y > c.Deadzone.Y {
+		desired.Y = target.Y - c.Deadzone.Y
+	} else if offset.Y < -c.Deadzone.Y {
+		desired.Y = target.Y + c.Deadzone.Y
+	}
+
+	if c.FollowSpeed > 0 {
+		// frame rate independent exponential smoothing
+		t := 1 - float32(math.Exp(float64(-c.FollowSpeed*dt)))
+		desired = c.Position.Lerp(desired, t)
+	}
+
+	c.Position = desired
+	c.ClampToBounds()
+}
+
+// ClampToBounds moves the camera so the visible area stays inside Bounds, centering it when Bounds is smaller
+func (c *Camera2D) ClampToBounds() {
+	if c.Bounds == nil {
+		return
+	}
+
+	half := c.ViewSize().Mul(0.5)
+	b := *c.Bounds
+
+	if b.Width() <= half.X*2 {
+		c.Position.X = b.Center().X
+	} else {
+		c.Position.X = min(max(c.Position.X, b.Min.X+half.X), b.Max.X-half.X)
+	}
+	if b.Height() <= half.Y*2 {
+		c.Position.Y = b.Center().Y
+	} else {
+		c.Position.Y = min(max(c.Position.Y, b.Min.Y+half.Y), b.Max.Y-half.Y)
+	}
+}
+
+// Shake starts a shake of up to intensity world units that fades out over duration seconds
+func (c *Camera2D) Shake(intensity, duration float32) {
+	if duration <= 0 {
+		return
+	}
+	c.shakeIntensity = max(intensity, c.shakeIntensity*c.shakeFade())
+	c.shakeDuration = duration
+	c.shakeTime = 0
+}
+
+func (c *Camera2D) shakeFade() float32 {
+	if c.shakeDuration <= 0 || c.shakeTime >= c.shakeDuration {
+		return 0
+	}
+	f := 1 - c.shakeTime/c.shakeDuration
+	return f * f
+}
+
+// Update advances the shake by dt seconds, call it once per tick
+func (c *Camera2D) Update(dt float32) {
+	if c.shakeDuration <= 0 {
+		return
+	}
+
+	c.shakeTime += dt
+	amount := c.shakeIntensity * c.shakeFade()
+	if amount <= 0 {
+		c.shakeDuration = 0
+		c.shakeOffset = notamath.Vec2{}
+		return
+	}
+
+	if c.shakeNoise == nil {
+		c.shakeNoise = notamath.NewSimplex(1)
+	}
+
+	// smooth noise looks like a camera being knocked about rather than jittering
+	t := c.shakeTime * 25
+	c.shakeOffset = notamath.Vec2{
+		X: c.shakeNoise.Noise2(t, 0) * amount,
+		Y: c.shakeNoise.Noise2(0, t) * amount,
+	}
+}
@@ -0,0 +1,110 @@
+package notagl
+
+import (
+	"NotaborEngine/notamath"
+	"math"
+)
+
+type Projection int
+
+const (
+	Perspective Projection = iota
+	Orthographic
+)
+
+// Camera3D holds a view and a projection. Set it as Renderer3D.Camera and its view-projection is sent to the
+// shader's uViewProjection uniform and used for frustum culling
+type Camera3D struct {
+	Position notamath.Vec3
+	Rotation notamath.Quat // the camera looks along -Z of this rotation
+
+	Projection  Projection
+	FovY        float32 // vertical field of view in radians, perspective only
+	OrthoHeight float32 // world units visible vertically, orthographic only
+	Aspect      float32 // width / height, the window keeps it in sync
+	Near, Far   float32
+}
+
+func NewCamera3D(fovY, aspect, near, far float32) *Camera3D {
+	return &Camera3D{
+		Rotation:    notamath.QuatIdentity(),
+		Projection:  Perspective,
+		FovY:        fovY,
+		OrthoHeight: 10,
+		Aspect:      aspect,
+		Near:        near,
+		Far:         far,
+	}
+}
+
+func (c *Camera3D) Forward() notamath.Vec3 {
+	return c.Rotation.Rotate(notamath.Vec3{Z: -1})
+}
+
+func (c *Camera3D) Right() notamath.Vec3 {
+	return c.Rotation.Rotate(notamath.Vec3{X: 1})
+}
+
+func (c *Camera3D) Up() notamath.Vec3 {
+	return c.Rotation.Rotate(notamath.Vec3{Y: 1})
+}
+
+// LookAt turns the camera towards target
+func (c *Camera3D) LookAt(target, up notamath.Vec3) {
+	c.Rotation = notamath.QuatLookRotation(target.Sub(c.Position), up)
+}
+
+// View returns the matrix taking world positions into camera space
+func (c *Camera3D) View() notamath.Mat4 {
+	return c.Rotation.Conjugate().Mat4().Mul(notamath.Mat4Translation(c.Position.Neg()))
+}
+
+func (c *Camera3D) ProjectionMatrix() notamath.Mat4 {
+	aspect := c.Aspect
+	if aspect <= 0 {
+		aspect = 1
+	}
+
+	if c.Projection == Orthographic {
+		h := c.OrthoHeight / 2
+		w := h * aspect
+		return notamath.Mat4Ortho(-w, w, -h, h, c.Near, c.Far)
+	}
+	return notamath.Mat4Perspective(c.FovY, aspect, c.Near, c.Far)
+}
+
+func (c *Camera3D) ViewProjection() notamath.Mat4 {
+	return c.ProjectionMatrix().Mul(c.View())
+}
+
+func (c *Camera3D) Frustum() notamath.Frustum {
+	return notamath.FrustumFromMat4(c.ViewProjection())
+}
+
+// ScreenRay returns the world space ray through a position in window pixels (top left origin) of the viewport
+func (c *Camera3D) ScreenRay(screen notamath.Po2, viewport notamath.Rect) notamath.Ray3 {
+	w, h := viewport.Width(), viewport.Height()
+	if w <= 0 || h <= 0 {
+		return notamath.Ray3{Origin: notamath.Po3(c.Position), Dir: c.Forward()}
+	}
+
+	x := (screen.X-viewport.Min.X)/w*2 - 1
+	y := 1 - (screen.Y-viewport.Min.Y)/h*2
+
+	inv := c.ViewProjection().Inverse()
+	near := inv.TransformPo3Projective(notamath.Po3{X: x, Y: y, Z: -1})
+	far := inv.TransformPo3Projective(notamath.Po3{X: x, Y: y, Z: 1})
+	return notamath.Ray3{Origin: near, Dir: far.SubPo(near).Normalize()}
+}
+
+// Orbit places the camera distance units from target, at yaw around +Y and pitch above the horizon, looking at it
+func (c *Camera3D) Orbit(target notamath.Vec3, yaw, pitch, distance float32) {
+	cp := float32(math.Cos(float64(pitch)))
+	offset := notamath.Vec3{
+		X: cp * float32(math.Sin(float64(yaw))),
+		Y: float32(math.Sin(float64(pitch))),
+		Z: cp * float32(math.Cos(float64(yaw))),
+	}
+	c.Position = target.Add(offset.Mul(distance))
+	c.LookAt(target, notamath.Vec3{Y: 1})
+}
@@ -3,9 +3,6 @@ package notagl
 import (
 	"NotaborEngine/notamath"
 	"NotaborEngine/notashader"
-	"unsafe"
-
-	"github.com/go-gl/gl/v4.6-core/gl"
 )
 
 type Vertex2D struct {
@@ -20,156 +17,86 @@ type DrawOrder2D struct {
 type Renderer2D struct {
 	Orders         []DrawOrder2D
 	CurrentTexture *Texture // Track current texture
-}
-
-func (r *Renderer2D) Submit(p *Polygon) {
-	var temp []DrawOrder2D
-
-	p.AddToOrders(&temp)
-
-	for _, order := range temp {
-		tris := Triangulate2D(order.Vertices)
-		if len(tris) == 0 {
-			continue
-		}
-		r.Orders = append(r.Orders, DrawOrder2D{
-			Vertices: tris,
-		})
-	}
-}
-
-type vertexFormat2D struct {
-	dimension int32 // should be 2
-	stride    int32
-}
-
-type GLBackend2D struct {
-	vao    uint32
-	vbo    uint32
-	format vertexFormat2D
-}
-
-func (b *GLBackend2D) Init() {
-	b.format = vertexFormat2D{
-		dimension: 2,
-		stride:    int32(unsafe.Sizeof(Vertex2D{})),
-	}
-
-	gl.CreateVertexArrays(1, &b.vao)
-	gl.CreateBuffers(1, &b.vbo)
-
-	// Position Attribute (Location 0)
-	gl.VertexArrayVertexBuffer(b.vao, 0, b.vbo, 0, b.format.stride)
-	gl.VertexArrayAttribFormat(b.vao, 0, 2, gl.FLOAT, false, 0)
-	gl.VertexArrayAttribBinding(b.vao, 0, 0)
-	gl.EnableVertexArrayAttrib(b.vao, 0)
 
-	// Color Attribute (Location 1)
-	colorOffset := uint32(unsafe.Sizeof(notamath.Po2{}))
-	gl.VertexArrayAttribFormat(b.vao, 1, 4, gl.FLOAT, false, colorOffset)
-	gl.VertexArrayAttribBinding(b.vao, 1, 0)
-	gl.EnableVertexArrayAttrib(b.vao, 1)
+	// Camera transforms everything submitted from world units to the screen, nil draws in raw NDC
+	Camera *Camera2D
 
-	// UV Attribute (Location 2)
-	uvOffset := uint32(unsafe.Sizeof(notamath.Po2{}) + unsafe.Sizeof(notashader.Color{}))
-	gl.VertexArrayAttribFormat(b.vao, 2, 2, gl.FLOAT, false, uvOffset)
-	gl.VertexArrayAttribBinding(b.vao, 2, 0)
-	gl.EnableVertexArrayAttrib(b.vao, 2)
+	// buffers reused between frames so submitting does not allocate once they have grown
+	scratch  []Vertex2D
+	tri      triangulator
+	vertices []Vertex2D
+	flat     []Vertex2D
 }
 
-func (b *GLBackend2D) BindVao() {
-	gl.BindVertexArray(b.vao)
+// Reset clears the orders, keeping the buffers for the next frame
+func (r *Renderer2D) Reset() {
+	r.Orders = r.Orders[:0]
+	r.vertices = r.vertices[:0]
 }
 
-func (b *GLBackend2D) UploadData(vertices interface{}) {
-	verts := vertices.([]Vertex2D)
-	gl.NamedBufferData(b.vbo, len(verts)*int(b.format.stride), gl.Ptr(verts), gl.DYNAMIC_DRAW)
-}
-
-func (r *Renderer2D) Flush(backend *GLBackend2D) {
-	if len(r.Orders) == 0 {
+func (r *Renderer2D) Submit(p *Polygon) {
+	if len(p.Vertices) < 3 {
 		return
 	}
 
-	var flat []Vertex2D
-	for _, order := range r.Orders {
-		flat = append(flat, order.Vertices...)
+	mat := p.Transform.Matrix()
+	if r.Camera != nil {
+		mat = r.Camera.ViewProjection().Mul(mat)
 	}
+	r.scratch = p.appendVertices(r.scratch[:0], mat)
 
-	if len(flat) == 0 {
+	start := len(r.vertices)
+	r.vertices = r.tri.triangulate(r.vertices, r.scratch)
+	if len(r.vertices) == start {
 		return
 	}
 
-	backend.UploadData(flat)
-	backend.BindVao()
-	gl.DrawArrays(gl.TRIANGLES, 0, int32(len(flat)))
+	// the cap stops appends to one order from running into the next
+	end := len(r.vertices)
+	r.Orders = append(r.Orders, DrawOrder2D{
+		Vertices: r.vertices[start:end:end],
+	})
 }
 
-func Triangulate2D(polygon []Vertex2D) []Vertex2D {
-	n := len(polygon)
-	if n < 3 {
-		return nil
+// TransformVertices2D writes src with transformed positions into dst and returns it, dst only grows when it is too
+// small. dst and src may be the same slice
+func TransformVertices2D(m notamath.Mat3, dst, src []Vertex2D) []Vertex2D {
+	if cap(dst) < len(src) {
+		dst = make([]Vertex2D, len(src))
 	}
+	dst = dst[:len(src)]
 
-	verts := append([]Vertex2D{}, polygon...)
-
-	// Enforce CCW winding (using Pos for math)
-	if !isCCWVertices(verts) {
-		for i, j := 0, len(verts)-1; i < j; i, j = i+1, j-1 {
-			verts[i], verts[j] = verts[j], verts[i]
-		}
-	}
-
-	var result []Vertex2D
-
-	for len(verts) > 3 {
-		earFound := false
-
-		for i := 0; i < len(verts); i++ {
-			prev := verts[(i-1+len(verts))%len(verts)]
-			curr := verts[i]
-			next := verts[(i+1)%len(verts)]
-
-			if isEarVertex(prev, curr, next, verts) {
-				result = append(result, prev, curr, next)
-
-				verts = append(verts[:i], verts[i+1:]...)
-				earFound = true
-				break
-			}
-		}
-
-		if !earFound {
-			return nil
-		}
+	a, b, c, d, e, f := m.M[0], m.M[1], m.M[2], m.M[3], m.M[4], m.M[5]
+	for i := range src {
+		v := src[i]
+		v.Pos = notamath.Po2{X: a*v.Pos.X + b*v.Pos.Y + c, Y: d*v.Pos.X + e*v.Pos.Y + f}
+		dst[i] = v
 	}
+	return dst
+}
 
-	result = append(result, verts[0], verts[1], verts[2])
-	return result
+func Triangulate2D(polygon []Vertex2D) []Vertex2D {
+	var t triangulator
+	return t.triangulate(nil, polygon)
 }
 
-// Helper functions to use Vertex2D for triangulation math
-func isCCWVertices(poly []Vertex2D) bool {
-	var area float32
-	for i := 0; i < len(poly); i++ {
-		a := poly[i].Pos
-		b := poly[(i+1)%len(poly)].Pos
-		area += (b.X - a.X) * (b.Y + a.Y)
-	}
-	return area < 0
+// triangulator ear clips polygons with notamath.TriangulateInto, keeping its buffers between calls
+type triangulator struct {
+	positions []notamath.Po2
+	indices   []int
+	work      []int
 }
 
-func isEarVertex(prev, curr, next Vertex2D, poly []Vertex2D) bool {
-	if notamath.Orient(prev.Pos, curr.Pos, next.Pos) <= 0 {
-		return false
+// triangulate appends the triangles of polygon to dst, carrying every vertex's color and UV along with its position.
+// dst is left unchanged if the polygon cannot be triangulated
+func (t *triangulator) triangulate(dst, polygon []Vertex2D) []Vertex2D {
+	t.positions = t.positions[:0]
+	for _, v := range polygon {
+		t.positions = append(t.positions, v.Pos)
 	}
-	for _, p := range poly {
-		if p.Pos == prev.Pos || p.Pos == curr.Pos || p.Pos == next.Pos {
-			continue
-		}
-		if PointInTriangle(p.Pos, prev.Pos, curr.Pos, next.Pos) {
-			return false
-		}
+	t.indices, t.work = notamath.TriangulateInto(t.indices[:0], t.work, t.positions)
+	for _, i := range t.indices {
+		dst = append(dst, polygon[i])
 	}
-	return true
+	return dst
 }
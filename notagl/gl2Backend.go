@@ -0,0 +1,78 @@
+//go:build !headless
+
+package notagl
+
+import (
+	"NotaborEngine/notamath"
+	"NotaborEngine/notashader"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+type vertexFormat2D struct {
+	dimension int32 // should be 2
+	stride    int32
+}
+
+type GLBackend2D struct {
+	vao    uint32
+	vbo    uint32
+	format vertexFormat2D
+}
+
+func (b *GLBackend2D) Init() {
+	b.format = vertexFormat2D{
+		dimension: 2,
+		stride:    int32(unsafe.Sizeof(Vertex2D{})),
+	}
+
+	gl.CreateVertexArrays(1, &b.vao)
+	gl.CreateBuffers(1, &b.vbo)
+
+	// Position Attribute (Location 0)
+	gl.VertexArrayVertexBuffer(b.vao, 0, b.vbo, 0, b.format.stride)
+	gl.VertexArrayAttribFormat(b.vao, 0, 2, gl.FLOAT, false, 0)
+	gl.VertexArrayAttribBinding(b.vao, 0, 0)
+	gl.EnableVertexArrayAttrib(b.vao, 0)
+
+	// Color Attribute (Location 1)
+	colorOffset := uint32(unsafe.Sizeof(notamath.Po2{}))
+	gl.VertexArrayAttribFormat(b.vao, 1, 4, gl.FLOAT, false, colorOffset)
+	gl.VertexArrayAttribBinding(b.vao, 1, 0)
+	gl.EnableVertexArrayAttrib(b.vao, 1)
+
+	// UV Attribute (Location 2)
+	uvOffset := uint32(unsafe.Sizeof(notamath.Po2{}) + unsafe.Sizeof(notashader.Color{}))
+	gl.VertexArrayAttribFormat(b.vao, 2, 2, gl.FLOAT, false, uvOffset)
+	gl.VertexArrayAttribBinding(b.vao, 2, 0)
+	gl.EnableVertexArrayAttrib(b.vao, 2)
+}
+
+func (b *GLBackend2D) BindVao() {
+	gl.BindVertexArray(b.vao)
+}
+
+func (b *GLBackend2D) UploadData(vertices interface{}) {
+	verts := vertices.([]Vertex2D)
+	gl.NamedBufferData(b.vbo, len(verts)*int(b.format.stride), gl.Ptr(verts), gl.DYNAMIC_DRAW)
+}
+
+func (r *Renderer2D) Flush(backend *GLBackend2D) {
+	if len(r.Orders) == 0 {
+		return
+	}
+
+	r.flat = r.flat[:0]
+	for _, order := range r.Orders {
+		r.flat = append(r.flat, order.Vertices...)
+	}
+
+	if len(r.flat) == 0 {
+		return
+	}
+
+	backend.UploadData(r.flat)
+	backend.BindVao()
+	gl.DrawArrays(gl.TRIANGLES, 0, int32(len(r.flat)))
+}
@@ -0,0 +1,64 @@
+package notagl
+
+import (
+	"math"
+	"testing"
+
+	"NotaborEngine/notamath"
+)
+
+// benchmarkScene is a frame of a few hundred shapes, convex and concave
+func benchmarkScene() []Polygon {
+	var scene []Polygon
+	for i := range 200 {
+		center := notamath.Po2{X: float32(i%20) * 0.1, Y: float32(i/20) * 0.1}
+		switch i % 3 {
+		case 0:
+			scene = append(scene, CreateCircle(center, 0.04))
+		case 1:
+			scene = append(scene, CreateRectangle(center, 0.05, 0.03))
+		default:
+			star := make([]notamath.Po2, 0, 10)
+			for k := range 10 {
+				r := float32(0.05)
+				if k%2 == 1 {
+					r = 0.02
+				}
+				a := float64(k) * 2 * math.Pi / 10
+				star = append(star, notamath.Po2{X: center.X + r*float32(math.Cos(a)), Y: center.Y + r*float32(math.Sin(a))})
+			}
+			scene = append(scene, CreatePolygons([][]notamath.Po2{star})...)
+		}
+	}
+	for i := range scene {
+		scene[i].Transform.SetRotation(float32(i) * 0.1)
+	}
+	return scene
+}
+
+// BenchmarkRenderer2DSubmit submits a frame with a renderer reused across frames, like a window does, and with a
+// fresh renderer per frame to show what reusing the buffers saves
+func BenchmarkRenderer2DSubmit(b *testing.B) {
+	scene := benchmarkScene()
+
+	b.Run("reused", func(b *testing.B) {
+		r := &Renderer2D{Camera: NewCamera2D(1280, 720, 10)}
+		b.ReportAllocs()
+		for b.Loop() {
+			r.Reset()
+			for i := range scene {
+				r.Submit(&scene[i])
+			}
+		}
+	})
+
+	b.Run("fresh", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			r := &Renderer2D{Camera: NewCamera2D(1280, 720, 10)}
+			for i := range scene {
+				r.Submit(&scene[i])
+			}
+		}
+	})
+}
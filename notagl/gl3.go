@@ -3,9 +3,6 @@ package notagl
 import (
 	"NotaborEngine/notamath"
 	"NotaborEngine/notashader"
-	"unsafe"
-
-	"github.com/go-gl/gl/v4.6-core/gl"
 )
 
 type DrawOrder3D struct {
@@ -19,80 +16,56 @@ type Vertex3D struct {
 
 type Renderer3D struct {
 	Orders []DrawOrder3D
-}
 
-func (r *Renderer3D) Submit(m *Mesh, alpha float32) {
-	m.AddToOrders(&r.Orders, alpha)
-}
+	// Frustum culls submitted meshes whose bounds are outside of it, nil disables culling
+	Frustum *notamath.Frustum
 
-type vertexFormat3D struct {
-	dimension int32 // should be 3
-	stride    int32
-}
+	// Camera provides the view-projection sent to the shader and replaces Frustum on every Reset, nil draws in
+	// raw clip space
+	Camera *Camera3D
 
-type GLBackend3D struct {
-	vao    uint32
-	vbo    uint32
-	format vertexFormat3D
-}
-
-func (b *GLBackend3D) Init() {
-	b.format = vertexFormat3D{
-		dimension: 3,
-		stride:    int32(unsafe.Sizeof(Vertex3D{})),
-	}
+	Submitted int // meshes submitted since the last Reset
+	Culled    int // meshes skipped by frustum culling since the last Reset
 
-	gl.CreateVertexArrays(1, &b.vao)
-	gl.CreateBuffers(1, &b.vbo)
-
-	gl.VertexArrayVertexBuffer(b.vao, 0, b.vbo, 0, b.format.stride)
-
-	gl.VertexArrayAttribFormat(
-		b.vao,
-		0,
-		3,
-		gl.FLOAT,
-		false,
-		0,
-	)
-	gl.VertexArrayAttribBinding(b.vao, 0, 0)
-	gl.EnableVertexArrayAttrib(b.vao, 0)
-
-	colorOffset := uint32(unsafe.Sizeof(notamath.Po3{}))
-
-	gl.VertexArrayAttribFormat(
-		b.vao,
-		1,
-		4,
-		gl.FLOAT,
-		false,
-		colorOffset,
-	)
-	gl.VertexArrayAttribBinding(b.vao, 1, 0)
-	gl.EnableVertexArrayAttrib(b.vao, 1)
+	vertices []Vertex3D
+	flat     []Vertex3D
 }
 
-func (b *GLBackend3D) UploadData(vertices interface{}) {
-	verts := vertices.([]Vertex3D)
-	gl.NamedBufferData(
-		b.vbo,
-		len(verts)*int(b.format.stride),
-		gl.Ptr(verts),
-		gl.DYNAMIC_DRAW,
-	)
+// SetViewProjection enables frustum culling against the given view-projection matrix
+func (r *Renderer3D) SetViewProjection(vp notamath.Mat4) {
+	f := notamath.FrustumFromMat4(vp)
+	r.Frustum = &f
 }
 
-func (r *Renderer3D) Flush(backend *GLBackend3D) {
-	var flat []Vertex3D
-	for _, order := range r.Orders {
-		tris := order.Vertices
-		flat = append(flat, tris...)
+// Reset clears the orders and the per-frame counters and picks up the camera's frustum
+func (r *Renderer3D) Reset() {
+	if r.Camera != nil {
+		r.SetViewProjection(r.Camera.ViewProjection())
 	}
+	r.Orders = r.Orders[:0]
+	r.vertices = r.vertices[:0]
+	r.Submitted = 0
+	r.Culled = 0
+}
 
-	if len(flat) == 0 {
-		return
+// Submit adds the mesh to the orders, unless it is outside the frustum. It reports whether the mesh was kept
+func (r *Renderer3D) Submit(m *Mesh, alpha float32) bool {
+	r.Submitted++
+	mat := m.Transform.InterpolatedMatrix(alpha)
+
+	if r.Frustum != nil && len(m.Vertices) > 0 {
+		bounds := m.LocalBounds().Transform(mat)
+		if !r.Frustum.IntersectsBox(bounds) {
+			r.Culled++
+			return false
+		}
 	}
-	backend.UploadData(flat)
-	gl.BindVertexArray(backend.vao)
-	gl.DrawArrays(gl.TRIANGLES, 0, int32(len(flat)))
+
+	start := len(r.vertices)
+	r.vertices = m.appendVertices(r.vertices, mat)
+	end := len(r.vertices)
+	r.Orders = append(r.Orders, DrawOrder3D{
+		Vertices: r.vertices[start:end:end],
+	})
+	return true
 }
@@ -0,0 +1,104 @@
+//go:build !headless
+
+package notagl
+
+import (
+	"NotaborEngine/notamath"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+type vertexFormat3D struct {
+	dimension int32 // should be 3
+	stride    int32
+}
+
+type GLBackend3D struct {
+	vao    uint32
+	vbo    uint32
+	format vertexFormat3D
+}
+
+func (b *GLBackend3D) Init() {
+	b.format = vertexFormat3D{
+		dimension: 3,
+		stride:    int32(unsafe.Sizeof(Vertex3D{})),
+	}
+
+	gl.CreateVertexArrays(1, &b.vao)
+	gl.CreateBuffers(1, &b.vbo)
+
+	gl.VertexArrayVertexBuffer(b.vao, 0, b.vbo, 0, b.format.stride)
+
+	gl.VertexArrayAttribFormat(
+		b.vao,
+		0,
+		3,
+		gl.FLOAT,
+		false,
+		0,
+	)
+	gl.VertexArrayAttribBinding(b.vao, 0, 0)
+	gl.EnableVertexArrayAttrib(b.vao, 0)
+
+	colorOffset := uint32(unsafe.Sizeof(notamath.Po3{}))
+
+	gl.VertexArrayAttribFormat(
+		b.vao,
+		1,
+		4,
+		gl.FLOAT,
+		false,
+		colorOffset,
+	)
+	gl.VertexArrayAttribBinding(b.vao, 1, 0)
+	gl.EnableVertexArrayAttrib(b.vao, 1)
+}
+
+func (b *GLBackend3D) UploadData(vertices interface{}) {
+	verts := vertices.([]Vertex3D)
+	gl.NamedBufferData(
+		b.vbo,
+		len(verts)*int(b.format.stride),
+		gl.Ptr(verts),
+		gl.DYNAMIC_DRAW,
+	)
+}
+
+func (r *Renderer3D) Flush(backend *GLBackend3D) {
+	r.flat = r.flat[:0]
+	for _, order := range r.Orders {
+		r.flat = append(r.flat, order.Vertices...)
+	}
+
+	if len(r.flat) == 0 {
+		return
+	}
+
+	r.uploadViewProjection()
+	backend.UploadData(r.flat)
+	gl.BindVertexArray(backend.vao)
+	gl.DrawArrays(gl.TRIANGLES, 0, int32(len(r.flat)))
+}
+
+// uploadViewProjection sets the uViewProjection uniform of the bound program, shaders without it are left alone
+func (r *Renderer3D) uploadViewProjection() {
+	var program int32
+	gl.GetIntegerv(gl.CURRENT_PROGRAM, &program)
+	if program == 0 {
+		return
+	}
+
+	loc := gl.GetUniformLocation(uint32(program), gl.Str("uViewProjection\x00"))
+	if loc < 0 {
+		return
+	}
+
+	vp := notamath.Mat4Identity()
+	if r.Camera != nil {
+		vp = r.Camera.ViewProjection()
+	}
+	// Mat4 is row-major, let GL transpose it
+	gl.UniformMatrix4fv(loc, 1, true, &vp.M[0])
+}
@@ -10,6 +10,26 @@ type Mesh struct {
 	Transform notamath.Transform3D
 	Color     notashader.Color
 	Colors    []notashader.Color
+
+	// BoundsDirty makes LocalBounds recompute its cached box, set it after changing Vertices in place. Assigning a
+	// different slice to Vertices is noticed without it
+	BoundsDirty bool
+	bounds      notamath.Box3
+	boundsOf    []notamath.Po3 // the Vertices the cached bounds were computed from
+}
+
+// CreateConvexHullMesh builds a mesh from the convex hull of a point cloud
+func CreateConvexHullMesh(points []notamath.Po3) Mesh {
+	m := Mesh{
+		Vertices:  notamath.ConvexHull3(points).Triangles(),
+		Transform: notamath.NewTransform3D(),
+		Color:     notashader.Color{R: 1, G: 1, B: 1, A: 1},
+	}
+
+	if len(m.Vertices) > 0 {
+		m.Fixate()
+	}
+	return m
 }
 
 func (m *Mesh) Fixate() {
@@ -28,9 +48,16 @@ func (m *Mesh) Fixate() {
 	m.Transform.Position = notamath.Vec3(center)
 }
 func (m *Mesh) AddToOrders(orders *[]DrawOrder3D, alpha float32) {
-	mat := m.Transform.InterpolatedMatrix(alpha)
+	m.addToOrders(orders, m.Transform.InterpolatedMatrix(alpha))
+}
+
+func (m *Mesh) addToOrders(orders *[]DrawOrder3D, mat notamath.Mat4) {
+	*orders = append(*orders, DrawOrder3D{
+		Vertices: m.appendVertices(nil, mat),
+	})
+}
 
-	verts := make([]Vertex3D, len(m.Vertices))
+func (m *Mesh) appendVertices(dst []Vertex3D, mat notamath.Mat4) []Vertex3D {
 	useGradient := len(m.Colors) == len(m.Vertices)
 
 	for i, v := range m.Vertices {
@@ -39,16 +66,31 @@ func (m *Mesh) AddToOrders(orders *[]DrawOrder3D, alpha float32) {
 			c = m.Colors[i]
 		}
 
-		verts[i] = Vertex3D{
+		dst = append(dst, Vertex3D{
 			Pos:   mat.TransformPo3(v),
 			Color: c,
-		}
+		})
 	}
 
-	*orders = append(*orders, DrawOrder3D{
-		Vertices: verts,
-	})
+	return dst
+}
+
+// LocalBounds returns the box around the untransformed vertices. It is only recomputed when Vertices is a different
+// slice than last time or BoundsDirty is set
+func (m *Mesh) LocalBounds() notamath.Box3 {
+	if m.BoundsDirty || !sameSlice(m.Vertices, m.boundsOf) {
+		m.bounds = notamath.Box3FromPoints(m.Vertices)
+		m.boundsOf = m.Vertices
+		m.BoundsDirty = false
+	}
+	return m.bounds
 }
+
+// sameSlice reports whether a and b view the same elements
+func sameSlice(a, b []notamath.Po3) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
 func (m *Mesh) SetHorizontalGradient(
 	left, right notashader.Color,
 ) {
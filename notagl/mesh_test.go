@@ -0,0 +1,32 @@
+package notagl
+
+import (
+	"NotaborEngine/notamath"
+	"testing"
+)
+
+func TestMeshLocalBoundsCache(t *testing.T) {
+	m := Mesh{Vertices: []notamath.Po3{{X: -1}, {X: 1, Y: 2}}}
+	want := notamath.Box3{Min: notamath.Po3{X: -1}, Max: notamath.Po3{X: 1, Y: 2}}
+	if got := m.LocalBounds(); got != want {
+		t.Fatalf("LocalBounds() = %v, want %v", got, want)
+	}
+
+	// moved in place, the cache holds until BoundsDirty is set
+	m.Vertices[1].Z = 5
+	if got := m.LocalBounds(); got != want {
+		t.Errorf("LocalBounds() = %v without BoundsDirty, want the cached %v", got, want)
+	}
+	m.BoundsDirty = true
+	want.Max.Z = 5
+	if got := m.LocalBounds(); got != want {
+		t.Errorf("LocalBounds() = %v after BoundsDirty, want %v", got, want)
+	}
+
+	// a new slice is noticed on its own
+	m.Vertices = []notamath.Po3{{Y: -3}}
+	want = notamath.Box3{Min: notamath.Po3{Y: -3}, Max: notamath.Po3{Y: -3}}
+	if got := m.LocalBounds(); got != want {
+		t.Errorf("LocalBounds() = %v after replacing Vertices, want %v", got, want)
+	}
+}
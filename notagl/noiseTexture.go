@@ -0,0 +1,32 @@
+package notagl
+
+// BakeNoise renders a grayscale texture by sampling noise at (x * scale, y * scale) for every pixel.
+// Values in [-1, 1] map to black..white and are clamped, call CreateGLTexture on the result to upload it
+func BakeNoise(width, height int32, scale float32, noise func(x, y float32) float32) *Texture {
+	data := make([]byte, int(width)*int(height)*4)
+
+	for y := int32(0); y < height; y++ {
+		for x := int32(0); x < width; x++ {
+			v := noise(float32(x)*scale, float32(y)*scale)
+			v = (v + 1) * 0.5
+			if v < 0 {
+				v = 0
+			} else if v > 1 {
+				v = 1
+			}
+
+			b := byte(v * 255)
+			i := (int(y)*int(width) + int(x)) * 4
+			data[i] = b
+			data[i+1] = b
+			data[i+2] = b
+			data[i+3] = 255
+		}
+	}
+
+	return &Texture{
+		Width:     width,
+		Height:    height,
+		ImageData: data,
+	}
+}
@@ -44,21 +44,30 @@ func (p *Polygon) AddToOrders(orders *[]DrawOrder2D) {
 		return
 	}
 
-	verts := make([]Vertex2D, len(p.Vertices))
+	*orders = append(*orders, DrawOrder2D{
+		Vertices: p.AppendVertices(nil),
+	})
+}
 
-	for i, v := range p.Vertices {
-		verts[i] = v
-		verts[i].Pos = p.Transform.TransformPoint(v.Pos)
+// AppendVertices appends the transformed vertices to dst with the polygon color filled in, reusing dst's capacity
+func (p *Polygon) AppendVertices(dst []Vertex2D) []Vertex2D {
+	return p.appendVertices(dst, p.Transform.Matrix())
+}
 
+func (p *Polygon) appendVertices(dst []Vertex2D, mat notamath.Mat3) []Vertex2D {
+	start := len(dst)
+	dst = append(dst, p.Vertices...)
+	verts := dst[start:]
+	TransformVertices2D(mat, verts, verts)
+
+	for i := range verts {
 		// Fallback to polygon color if vertex color is zero
-		if v.Color == (notashader.Color{}) {
+		if verts[i].Color == (notashader.Color{}) {
 			verts[i].Color = p.Color
 		}
 	}
 
-	*orders = append(*orders, DrawOrder2D{
-		Vertices: verts,
-	})
+	return dst
 }
 
 func (p *Polygon) SetVerticalGradient(top, bottom notashader.Color) {
@@ -136,47 +145,64 @@ func CreateRectangle(center notamath.Po2, w, h float32) Polygon {
 	return p
 }
 
+// CreateConvexHull builds a polygon from the convex hull of points, centered on its own position like CreateRectangle
+func CreateConvexHull(points []notamath.Po2) Polygon {
+	hull := notamath.ConvexHull2(points, false)
+
+	p := Polygon{
+		Vertices:  make([]Vertex2D, len(hull)),
+		Transform: notamath.NewTransform2D(),
+		Color:     notashader.Color{R: 1, G: 1, B: 1, A: 1},
+	}
+	for i, v := range hull {
+		p.Vertices[i].Pos = v
+	}
+
+	p.Fixate()
+	return p
+}
+
+// CreatePolygons builds one polygon per outline of a polygon operation result, with its holes bridged in
+func CreatePolygons(rings [][]notamath.Po2) []Polygon {
+	shapes := notamath.GroupRings(rings)
+	polys := make([]Polygon, 0, len(shapes))
+
+	for _, s := range shapes {
+		outline := s.Bridged()
+		p := Polygon{
+			Vertices:  make([]Vertex2D, len(outline)),
+			Transform: notamath.NewTransform2D(),
+			Color:     notashader.Color{R: 1, G: 1, B: 1, A: 1},
+		}
+		for i, v := range outline {
+			p.Vertices[i].Pos = v
+		}
+
+		p.Fixate()
+		polys = append(polys, p)
+	}
+
+	return polys
+}
+
 func CreateCircle(center notamath.Po2, radius float32) Polygon {
 	size := radius * 2
 	return CreateRectangle(center, size, size)
 }
 
+// IsCCW reports whether the polygon winds counter-clockwise, see notamath.SignedArea
 func IsCCW(poly []notamath.Po2) bool {
-	var area float32
-	for i := 0; i < len(poly); i++ {
-		a := poly[i]
-		b := poly[(i+1)%len(poly)]
-		area += (b.X - a.X) * (b.Y + a.Y)
-	}
-	return area < 0
+	return notamath.SignedArea(poly) > 0
 }
 
+// PointInTriangle is notamath.PointInTriangle
 func PointInTriangle(p, a, b, c notamath.Po2) bool {
-	o1 := notamath.Orient(a, b, p)
-	o2 := notamath.Orient(b, c, p)
-	o3 := notamath.Orient(c, a, p)
-
-	hasNeg := (o1 < 0) || (o2 < 0) || (o3 < 0)
-	hasPos := (o1 > 0) || (o2 > 0) || (o3 > 0)
-
-	return !(hasNeg && hasPos)
+	return notamath.PointInTriangle(p, a, b, c)
 }
 
+// IsEar is notamath.IsEar
 func IsEar(prev, curr, next notamath.Po2, poly []notamath.Po2) bool {
-	// Must be convex (CCW polygon)
-	if notamath.Orient(prev, curr, next) <= 0 {
-		return false
-	}
-
-	for _, p := range poly {
-		if p == prev || p == curr || p == next {
-			continue
-		}
-		if PointInTriangle(p, prev, curr, next) {
-			return false
-		}
-	}
-	return true
+	return notamath.IsEar(prev, curr, next, poly)
 }
 
 func polygonCentroid(poly []Vertex2D) notamath.Po2 {
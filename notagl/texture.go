@@ -9,8 +9,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-
-	"github.com/go-gl/gl/v4.6-core/gl"
 )
 
 type Texture struct {
@@ -73,59 +71,3 @@ func LoadImageData(path string) (*Texture, error) {
 		Loaded:    false,
 	}, nil
 }
-
-// CreateGLTexture creates the actual OpenGL texture from stored image data
-func (t *Texture) CreateGLTexture() error {
-	if t.Loaded {
-		return nil // Already created
-	}
-
-	if t.ImageData == nil {
-		return fmt.Errorf("no image data to upload")
-	}
-
-	gl.GenTextures(1, &t.ID)
-	gl.BindTexture(gl.TEXTURE_2D, t.ID)
-
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.REPEAT)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.REPEAT)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
-
-	gl.TexImage2D(
-		gl.TEXTURE_2D,
-		0,
-		gl.RGBA,
-		t.Width,
-		t.Height,
-		0,
-		gl.RGBA,
-		gl.UNSIGNED_BYTE,
-		gl.Ptr(t.ImageData),
-	)
-
-	gl.GenerateMipmap(gl.TEXTURE_2D)
-	gl.BindTexture(gl.TEXTURE_2D, 0)
-
-	t.Loaded = true
-	return nil
-}
-
-func (t *Texture) Bind(unit uint32) {
-	if !t.Loaded {
-		panic("texture not loaded into OpenGL")
-	}
-	gl.ActiveTexture(gl.TEXTURE0 + unit)
-	gl.BindTexture(gl.TEXTURE_2D, t.ID)
-}
-
-func (t *Texture) Unbind() {
-	gl.BindTexture(gl.TEXTURE_2D, 0)
-}
-
-func (t *Texture) Delete() {
-	if t.Loaded {
-		gl.DeleteTextures(1, &t.ID)
-		t.Loaded = false
-	}
-}
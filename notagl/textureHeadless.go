@@ -0,0 +1,24 @@
+//go:build headless
+
+package notagl
+
+import "errors"
+
+// errHeadless is returned by the calls that need OpenGL in a headless build
+var errHeadless = errors.New("notagl: built with the headless tag, there is no OpenGL")
+
+// CreateGLTexture fails, a headless build has no OpenGL to upload to
+func (t *Texture) CreateGLTexture() error {
+	return errHeadless
+}
+
+// Bind panics like it does on a texture that was never uploaded, in a headless build none is
+func (t *Texture) Bind(unit uint32) {
+	panic("texture not loaded into OpenGL")
+}
+
+func (t *Texture) Unbind() {}
+
+func (t *Texture) Delete() {
+	t.Loaded = false
+}
@@ -0,0 +1,19 @@
+package notagl
+
+import "NotaborEngine/notamath"
+
+// TraceAlpha traces the outlines of every area whose alpha is at least threshold with marching squares.
+// Points are in pixels with the origin at the bottom left, matching the flipped image data. Outlines are
+// counter-clockwise and holes clockwise, pass the rings through notamath.SimplifyDouglasPeucker before
+// building colliders
+func (t *Texture) TraceAlpha(threshold uint8) [][]notamath.Po2 {
+	w, h := int(t.Width), int(t.Height)
+	if len(t.ImageData) < w*h*4 {
+		return nil
+	}
+
+	alpha := func(x, y int) float32 {
+		return float32(t.ImageData[(y*w+x)*4+3])
+	}
+	return notamath.MarchingSquares(w, h, alpha, float32(threshold))
+}
@@ -0,0 +1,63 @@
+package notamath
+
+type BezierQuadratic[T vector[T]] struct {
+	P0, P1, P2 T
+}
+
+type BezierCubic[T vector[T]] struct {
+	P0, P1, P2, P3 T
+}
+
+type (
+	BezierQuadratic2 = BezierQuadratic[Vec2]
+	BezierQuadratic3 = BezierQuadratic[Vec3]
+	BezierCubic2     = BezierCubic[Vec2]
+	BezierCubic3     = BezierCubic[Vec3]
+)
+
+func (b BezierQuadratic[T]) Point(t float32) T {
+	u := 1 - t
+	return b.P0.Mul(u * u).Add(b.P1.Mul(2 * u * t)).Add(b.P2.Mul(t * t))
+}
+
+func (b BezierQuadratic[T]) Derivative(t float32) T {
+	u := 1 - t
+	return b.P1.Sub(b.P0).Mul(2 * u).Add(b.P2.Sub(b.P1).Mul(2 * t))
+}
+
+func (b BezierQuadratic[T]) SecondDerivative(float32) T {
+	return b.P2.Sub(b.P1.Mul(2)).Add(b.P0).Mul(2)
+}
+
+func (b BezierCubic[T]) Point(t float32) T {
+	u := 1 - t
+	return b.P0.Mul(u * u * u).
+		Add(b.P1.Mul(3 * u * u * t)).
+		Add(b.P2.Mul(3 * u * t * t)).
+		Add(b.P3.Mul(t * t * t))
+}
+
+func (b BezierCubic[T]) Derivative(t float32) T {
+	u := 1 - t
+	return b.P1.Sub(b.P0).Mul(3 * u * u).
+		Add(b.P2.Sub(b.P1).Mul(6 * u * t)).
+		Add(b.P3.Sub(b.P2).Mul(3 * t * t))
+}
+
+func (b BezierCubic[T]) SecondDerivative(t float32) T {
+	u := 1 - t
+	a := b.P2.Sub(b.P1.Mul(2)).Add(b.P0)
+	c := b.P3.Sub(b.P2.Mul(2)).Add(b.P1)
+	return a.Mul(6 * u).Add(c.Mul(6 * t))
+}
+
+// Split cuts the curve at t into two curves covering [0, t] and [t, 1]
+func (b BezierCubic[T]) Split(t float32) (BezierCubic[T], BezierCubic[T]) {
+	lerp := func(a, c T) T { return a.Add(c.Sub(a).Mul(t)) }
+
+	p01, p12, p23 := lerp(b.P0, b.P1), lerp(b.P1, b.P2), lerp(b.P2, b.P3)
+	p012, p123 := lerp(p01, p12), lerp(p12, p23)
+	mid := lerp(p012, p123)
+
+	return BezierCubic[T]{b.P0, p01, p012, mid}, BezierCubic[T]{mid, p123, p23, b.P3}
+}
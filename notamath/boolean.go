@@ -0,0 +1,404 @@
+package notamath
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// BooleanOp selects how PolygonBoolean combines two shapes
+type BooleanOp int
+
+const (
+	OpUnion BooleanOp = iota
+	OpIntersection
+	OpDifference
+	OpXor
+)
+
+// fillRule decides which points are inside a set of rings
+type fillRule int
+
+const (
+	fillEvenOdd fillRule = iota
+	fillNonZero
+)
+
+// PolygonBoolean combines the shapes a and b. Each shape is a list of rings where holes are simply more rings,
+// a point is inside when it is enclosed an odd number of times so the winding of the input does not matter.
+// The result rings are counter-clockwise for outlines and clockwise for holes, GroupRings pairs them up.
+//
+// This is not a sweep line clipper like Vatti or Martinez-Rueda: edges are split against every edge whose x range
+// overlaps theirs and each piece is classified by testing a point beside it against all input edges, so the cost
+// grows with pieces times edges. That is fine for game shapes of a few thousand edges, not for map sized data.
+// When a ring of the result fails to close, which only happens with degenerate input, the closed rings are still
+// returned together with an error
+func PolygonBoolean(op BooleanOp, a, b [][]Po2) ([][]Po2, error) {
+	return clipRings(op, a, fillEvenOdd, b, fillEvenOdd)
+}
+
+func PolygonUnion(a, b [][]Po2) ([][]Po2, error) {
+	return PolygonBoolean(OpUnion, a, b)
+}
+
+func PolygonIntersection(a, b [][]Po2) ([][]Po2, error) {
+	return PolygonBoolean(OpIntersection, a, b)
+}
+
+// PolygonDifference removes b from a
+func PolygonDifference(a, b [][]Po2) ([][]Po2, error) {
+	return PolygonBoolean(OpDifference, a, b)
+}
+
+func PolygonXor(a, b [][]Po2) ([][]Po2, error) {
+	return PolygonBoolean(OpXor, a, b)
+}
+
+// the clipper works in float64 so that intersections computed for two edges land on the same point
+
+type bpoint struct {
+	x, y float64
+}
+
+func (p bpoint) sub(q bpoint) bpoint {
+	return bpoint{p.x - q.x, p.y - q.y}
+}
+
+func (p bpoint) cross(q bpoint) float64 {
+	return p.x*q.y - p.y*q.x
+}
+
+func (p bpoint) dot(q bpoint) float64 {
+	return p.x*q.x + p.y*q.y
+}
+
+type bedge struct {
+	a, b  bpoint
+	owner int
+	cuts  []bpoint
+}
+
+type bsegment struct {
+	from, to int
+}
+
+// vertexPool merges points closer than eps so split edges share their endpoints exactly
+type vertexPool struct {
+	eps    float64
+	points []bpoint
+	cells  map[[2]int64][]int
+}
+
+func (vp *vertexPool) add(p bpoint) int {
+	cx := int64(math.Floor(p.x / vp.eps))
+	cy := int64(math.Floor(p.y / vp.eps))
+
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, i := range vp.cells[[2]int64{cx + dx, cy + dy}] {
+				q := vp.points[i]
+				if math.Abs(q.x-p.x) <= vp.eps && math.Abs(q.y-p.y) <= vp.eps {
+					return i
+				}
+			}
+		}
+	}
+
+	i := len(vp.points)
+	vp.points = append(vp.points, p)
+	vp.cells[[2]int64{cx, cy}] = append(vp.cells[[2]int64{cx, cy}], i)
+	return i
+}
+
+func clipRings(op BooleanOp, a [][]Po2, ruleA fillRule, b [][]Po2, ruleB fillRule) ([][]Po2, error) {
+	var edges []bedge
+	edges = appendRingEdges(edges, a, 0)
+	edges = appendRingEdges(edges, b, 1)
+	if len(edges) == 0 {
+		return nil, nil
+	}
+
+	// everything is scaled by the size of the input so tolerances work for any units
+	minP, maxP := edges[0].a, edges[0].a
+	for _, e := range edges {
+		for _, p := range [2]bpoint{e.a, e.b} {
+			minP = bpoint{math.Min(minP.x, p.x), math.Min(minP.y, p.y)}
+			maxP = bpoint{math.Max(maxP.x, p.x), math.Max(maxP.y, p.y)}
+		}
+	}
+	scale := math.Max(math.Hypot(maxP.x-minP.x, maxP.y-minP.y), 1e-12)
+	snap := scale * 1e-9
+	probe := scale * 1e-7
+
+	splitEdges(edges, snap)
+
+	// split every edge at its cuts and drop duplicates, shared edges of a and b become one segment
+	pool := vertexPool{eps: snap, cells: make(map[[2]int64][]int)}
+	seen := make(map[bsegment]bool)
+	var segments []bsegment
+	for i := range edges {
+		e := &edges[i]
+		dir := e.b.sub(e.a)
+		slices.SortFunc(e.cuts, func(p, q bpoint) int {
+			tp, tq := p.sub(e.a).dot(dir), q.sub(e.a).dot(dir)
+			switch {
+			case tp < tq:
+				return -1
+			case tp > tq:
+				return 1
+			}
+			return 0
+		})
+
+		prev := pool.add(e.a)
+		for _, c := range append(e.cuts, e.b) {
+			next := pool.add(c)
+			if next == prev {
+				continue
+			}
+			key := bsegment{min(prev, next), max(prev, next)}
+			if !seen[key] {
+				seen[key] = true
+				segments = append(segments, key)
+			}
+			prev = next
+		}
+	}
+
+	inside := func(p bpoint) bool {
+		inA := ringsContain(edges, 0, ruleA, p)
+		inB := ringsContain(edges, 1, ruleB, p)
+		switch op {
+		case OpIntersection:
+			return inA && inB
+		case OpDifference:
+			return inA && !inB
+		case OpXor:
+			return inA != inB
+		}
+		return inA || inB
+	}
+
+	// keep segments with the result on exactly one side, directed so the inside is on the left
+	var kept []bsegment
+	for _, s := range segments {
+		p, q := pool.points[s.from], pool.points[s.to]
+		d := q.sub(p)
+		l := math.Hypot(d.x, d.y)
+		mid := bpoint{(p.x + q.x) / 2, (p.y + q.y) / 2}
+		n := bpoint{-d.y / l * probe, d.x / l * probe}
+
+		left := inside(bpoint{mid.x + n.x, mid.y + n.y})
+		right := inside(bpoint{mid.x - n.x, mid.y - n.y})
+		switch {
+		case left && !right:
+			kept = append(kept, s)
+		case right && !left:
+			kept = append(kept, bsegment{s.to, s.from})
+		}
+	}
+
+	return chainSegments(pool.points, kept, snap)
+}
+
+func appendRingEdges(edges []bedge, rings [][]Po2, owner int) []bedge {
+	for _, ring := range rings {
+		for i := range ring {
+			p := ring[i]
+			q := ring[(i+1)%len(ring)]
+			if p == q {
+				continue
+			}
+			edges = append(edges, bedge{
+				a:     bpoint{float64(p.X), float64(p.Y)},
+				b:     bpoint{float64(q.X), float64(q.Y)},
+				owner: owner,
+			})
+		}
+	}
+	return edges
+}
+
+// splitEdges records every crossing and touching point between two edges on both of them. The edges are swept in
+// order of their left end so only pairs whose x ranges overlap are tested
+func splitEdges(edges []bedge, snap float64) {
+	order := make([]int, len(edges))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		return cmp.Compare(math.Min(edges[i].a.x, edges[i].b.x), math.Min(edges[j].a.x, edges[j].b.x))
+	})
+
+	for oi, i := range order {
+		ei := &edges[i]
+		right := math.Max(ei.a.x, ei.b.x) + snap
+		for _, j := range order[oi+1:] {
+			ej := &edges[j]
+			if math.Min(ej.a.x, ej.b.x) > right {
+				break
+			}
+
+			if math.Max(ei.a.y, ei.b.y)+snap < math.Min(ej.a.y, ej.b.y) ||
+				math.Max(ej.a.y, ej.b.y)+snap < math.Min(ei.a.y, ei.b.y) {
+				continue
+			}
+
+			r := ei.b.sub(ei.a)
+			s := ej.b.sub(ej.a)
+			denom := r.cross(s)
+			lr := math.Hypot(r.x, r.y)
+			ls := math.Hypot(s.x, s.y)
+
+			if math.Abs(denom) > snap*(lr+ls) {
+				qp := ej.a.sub(ei.a)
+				t := qp.cross(s) / denom
+				u := qp.cross(r) / denom
+				et := snap / lr
+				eu := snap / ls
+				if t < -et || t > 1+et || u < -eu || u > 1+eu {
+					continue
+				}
+				p := bpoint{ei.a.x + r.x*t, ei.a.y + r.y*t}
+				ei.cuts = append(ei.cuts, p)
+				ej.cuts = append(ej.cuts, p)
+				continue
+			}
+
+			// parallel, only collinear overlaps matter: each edge is cut where the other one ends
+			if math.Abs(ej.a.sub(ei.a).cross(r))/lr > snap {
+				continue
+			}
+			addCollinearCuts(ei, ej)
+			addCollinearCuts(ej, ei)
+		}
+	}
+}
+
+func addCollinearCuts(e, o *bedge) {
+	d := e.b.sub(e.a)
+	l := d.dot(d)
+	for _, p := range [2]bpoint{o.a, o.b} {
+		t := p.sub(e.a).dot(d) / l
+		if t > 0 && t < 1 {
+			e.cuts = append(e.cuts, p)
+		}
+	}
+}
+
+// ringsContain tests p against the original edges of one operand
+func ringsContain(edges []bedge, owner int, rule fillRule, p bpoint) bool {
+	winding := 0
+	for _, e := range edges {
+		if e.owner != owner {
+			continue
+		}
+		if e.a.y <= p.y {
+			if e.b.y > p.y && e.b.sub(e.a).cross(p.sub(e.a)) > 0 {
+				winding++
+			}
+		} else if e.b.y <= p.y && e.b.sub(e.a).cross(p.sub(e.a)) < 0 {
+			winding--
+		}
+	}
+
+	if rule == fillNonZero {
+		return winding != 0
+	}
+	return winding%2 != 0
+}
+
+// chainSegments links directed segments into closed rings, at shared vertices it takes the sharpest left turn so
+// shapes touching at a point come out as separate rings. A chain that runs into a dead end is reported, the rings
+// that did close are returned regardless
+func chainSegments(points []bpoint, segments []bsegment, snap float64) ([][]Po2, error) {
+	outgoing := make(map[int][]int)
+	for i, s := range segments {
+		outgoing[s.from] = append(outgoing[s.from], i)
+	}
+	used := make([]bool, len(segments))
+
+	var rings [][]Po2
+	var err error
+	for start := range segments {
+		if used[start] {
+			continue
+		}
+		used[start] = true
+
+		ring := []int{segments[start].from}
+		cur := segments[start]
+		closed := false
+
+		for len(ring) <= len(segments) {
+			if cur.to == ring[0] {
+				closed = true
+				break
+			}
+			ring = append(ring, cur.to)
+
+			din := points[cur.to].sub(points[cur.from])
+			next, best := -1, math.Inf(-1)
+			for _, k := range outgoing[cur.to] {
+				if used[k] {
+					continue
+				}
+				dout := points[segments[k].to].sub(points[segments[k].from])
+				if turn := math.Atan2(din.cross(dout), din.dot(dout)); turn > best {
+					next, best = k, turn
+				}
+			}
+			if next < 0 {
+				break
+			}
+			used[next] = true
+			cur = segments[next]
+		}
+
+		if !closed {
+			if err == nil {
+				p := points[ring[0]]
+				err = fmt.Errorf("result ring starting at (%g, %g) did not close after %d points", p.x, p.y, len(ring))
+			}
+			continue
+		}
+		if r := cleanRing(points, ring, snap); r != nil {
+			rings = append(rings, r)
+		}
+	}
+
+	return rings, err
+}
+
+// cleanRing drops points lying on a straight line between their neighbours and rejects empty rings
+func cleanRing(points []bpoint, ring []int, snap float64) []Po2 {
+	pts := make([]bpoint, len(ring))
+	for i, j := range ring {
+		pts[i] = points[j]
+	}
+
+	for changed := true; changed && len(pts) >= 3; {
+		changed = false
+		for i := 0; i < len(pts) && len(pts) >= 3; i++ {
+			prev := pts[(i+len(pts)-1)%len(pts)]
+			next := pts[(i+1)%len(pts)]
+			d := next.sub(prev)
+			l := math.Hypot(d.x, d.y)
+			if l == 0 || math.Abs(d.cross(pts[i].sub(prev)))/l <= snap {
+				pts = slices.Delete(pts, i, i+1)
+				changed = true
+				i--
+			}
+		}
+	}
+	if len(pts) < 3 {
+		return nil
+	}
+
+	out := make([]Po2, len(pts))
+	for i, p := range pts {
+		out[i] = Po2{float32(p.x), float32(p.y)}
+	}
+	return out
+}
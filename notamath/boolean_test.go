@@ -0,0 +1,67 @@
+package notamath
+
+import (
+	"math"
+	"testing"
+)
+
+func square(x, y, size float32) []Po2 {
+	return []Po2{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}}
+}
+
+func totalArea(rings [][]Po2) float32 {
+	var a float32
+	for _, r := range rings {
+		a += SignedArea(r)
+	}
+	return a
+}
+
+func TestPolygonBooleanSquares(t *testing.T) {
+	a := [][]Po2{square(0, 0, 2)}
+	b := [][]Po2{square(1, 1, 2)}
+	cases := []struct {
+		op   BooleanOp
+		want float32
+	}{
+		{OpUnion, 7},
+		{OpIntersection, 1},
+		{OpDifference, 3},
+		{OpXor, 6},
+	}
+	for _, c := range cases {
+		rings, err := PolygonBoolean(c.op, a, b)
+		if err != nil {
+			t.Fatalf("op %d: %v", c.op, err)
+		}
+		if got := totalArea(rings); math.Abs(float64(got-c.want)) > 1e-4 {
+			t.Errorf("op %d: area %v, want %v", c.op, got, c.want)
+		}
+	}
+}
+
+func TestPolygonBooleanHole(t *testing.T) {
+	rings, err := PolygonDifference([][]Po2{square(0, 0, 4)}, [][]Po2{square(1, 1, 2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rings) != 2 {
+		t.Fatalf("got %d rings, want an outline and a hole", len(rings))
+	}
+	if got := totalArea(rings); math.Abs(float64(got-12)) > 1e-4 {
+		t.Errorf("area %v, want 12", got)
+	}
+}
+
+func TestChainSegmentsReportsOpenRings(t *testing.T) {
+	points := []bpoint{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {5, 5}, {6, 5}}
+	segments := []bsegment{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {4, 5}}
+
+	rings, err := chainSegments(points, segments, 1e-9)
+	if err == nil {
+		t.Error("the dangling segment should be reported")
+	}
+	if len(rings) != 1 {
+		t.Errorf("got %d rings, the closed square should still be returned", len(rings))
+	}
+}
@@ -0,0 +1,91 @@
+package notamath
+
+// Box3 is an axis aligned box between Min and Max
+type Box3 struct {
+	Min, Max Po3
+}
+
+func Box3FromCenter(center Po3, size Vec3) Box3 {
+	h := size.Mul(0.5)
+	return Box3{
+		Min: Po3(Vec3(center).Sub(h)),
+		Max: center.Add(h),
+	}
+}
+
+// Box3FromPoints returns the smallest box containing every point
+func Box3FromPoints(points []Po3) Box3 {
+	if len(points) == 0 {
+		return Box3{}
+	}
+
+	b := Box3{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		b = b.Expand(p)
+	}
+	return b
+}
+
+func (b Box3) Center() Po3 {
+	return Po3{
+		(b.Min.X + b.Max.X) / 2,
+		(b.Min.Y + b.Max.Y) / 2,
+		(b.Min.Z + b.Max.Z) / 2,
+	}
+}
+
+func (b Box3) Size() Vec3 {
+	return b.Max.SubPo(b.Min)
+}
+
+func (b Box3) Contains(p Po3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+func (b Box3) Intersects(o Box3) bool {
+	return b.Min.X <= o.Max.X && b.Max.X >= o.Min.X &&
+		b.Min.Y <= o.Max.Y && b.Max.Y >= o.Min.Y &&
+		b.Min.Z <= o.Max.Z && b.Max.Z >= o.Min.Z
+}
+
+// Expand grows the box to include p
+func (b Box3) Expand(p Po3) Box3 {
+	return Box3{
+		Min: Po3{min(b.Min.X, p.X), min(b.Min.Y, p.Y), min(b.Min.Z, p.Z)},
+		Max: Po3{max(b.Max.X, p.X), max(b.Max.Y, p.Y), max(b.Max.Z, p.Z)},
+	}
+}
+
+func (b Box3) Union(o Box3) Box3 {
+	return b.Expand(o.Min).Expand(o.Max)
+}
+
+func (b Box3) ClosestPoint(p Po3) Po3 {
+	return Po3{
+		X: clamp(p.X, b.Min.X, b.Max.X),
+		Y: clamp(p.Y, b.Min.Y, b.Max.Y),
+		Z: clamp(p.Z, b.Min.Z, b.Max.Z),
+	}
+}
+
+// Distance from the surface of the box, 0 for points inside
+func (b Box3) Distance(p Po3) float32 {
+	return b.ClosestPoint(p).Distance(p)
+}
+
+// Transform returns the axis aligned box enclosing the transformed box
+func (b Box3) Transform(m Mat4) Box3 {
+	c := m.TransformPo3(b.Center())
+	h := b.Size().Mul(0.5)
+
+	// each output extent is the sum of the absolute contributions of the input extents
+	e := Vec3{
+		X: absf(m.M[0])*h.X + absf(m.M[1])*h.Y + absf(m.M[2])*h.Z,
+		Y: absf(m.M[4])*h.X + absf(m.M[5])*h.Y + absf(m.M[6])*h.Z,
+		Z: absf(m.M[8])*h.X + absf(m.M[9])*h.Y + absf(m.M[10])*h.Z,
+	}
+
+	return Box3{Min: Po3(Vec3(c).Sub(e)), Max: c.Add(e)}
+}
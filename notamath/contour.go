@@ -0,0 +1,123 @@
+package notamath
+
+// MarchingSquares traces the outlines where value crosses iso on a width x height grid of samples. Sample (x, y)
+// sits at (x + 0.5, y + 0.5) and everything outside the grid counts as below iso, so every ring is closed. Where an
+// outline crosses out of the grid it runs along its border, at 0 and width or height.
+// Rings have the area at or above iso on their left: outlines are counter-clockwise and holes clockwise
+func MarchingSquares(width, height int, value func(x, y int) float32, iso float32) [][]Po2 {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+
+	outside := func(x, y int) bool {
+		return x < 0 || y < 0 || x >= width || y >= height
+	}
+	sample := func(x, y int) float32 {
+		if outside(x, y) {
+			return iso - 1
+		}
+		return value(x, y)
+	}
+
+	// grid edges get ids so segments can be linked without comparing floats
+	stride := width + 2
+	hEdge := func(x, y int) int { return ((y+1)*stride + x + 1) * 2 }
+	vEdge := func(x, y int) int { return ((y+1)*stride+x+1)*2 + 1 }
+
+	type segment struct {
+		to    int
+		point Po2
+	}
+	starts := make(map[int]segment)
+	var order []int
+
+	for y := -1; y < height; y++ {
+		for x := -1; x < width; x++ {
+			// corners counter-clockwise from the bottom left and the edges leaving them
+			cx := [4]int{x, x + 1, x + 1, x}
+			cy := [4]int{y, y, y + 1, y + 1}
+			ids := [4]int{hEdge(x, y), vEdge(x+1, y), hEdge(x, y+1), vEdge(x, y)}
+
+			var v [4]float32
+			var in [4]bool
+			mask := 0
+			for k := 0; k < 4; k++ {
+				v[k] = sample(cx[k], cy[k])
+				in[k] = v[k] >= iso
+				if in[k] {
+					mask |= 1 << k
+				}
+			}
+			if mask == 0 || mask == 15 {
+				continue
+			}
+
+			crossing := func(k int) Po2 {
+				j := (k + 1) % 4
+				t := clamp((iso-v[k])/(v[j]-v[k]), 0, 1)
+				// outside samples have no real value to interpolate, the border lies halfway to them
+				if outside(cx[k], cy[k]) || outside(cx[j], cy[j]) {
+					t = 0.5
+				}
+				return Po2{
+					X: float32(cx[k]) + 0.5 + float32(cx[j]-cx[k])*t,
+					Y: float32(cy[k]) + 0.5 + float32(cy[j]-cy[k])*t,
+				}
+			}
+
+			// the outline leaves the cell where the inside ends going counter-clockwise and comes back in where it
+			// starts, in saddles the centre decides whether the two inside corners are joined
+			var exits, entries []int
+			for k := 0; k < 4; k++ {
+				j := (k + 1) % 4
+				if in[k] && !in[j] {
+					exits = append(exits, k)
+				} else if !in[k] && in[j] {
+					entries = append(entries, k)
+				}
+			}
+
+			link := func(exit, entry int) {
+				starts[ids[exit]] = segment{to: ids[entry], point: crossing(exit)}
+				order = append(order, ids[exit])
+			}
+
+			if len(exits) == 1 {
+				link(exits[0], entries[0])
+				continue
+			}
+
+			joined := (v[0]+v[1]+v[2]+v[3])/4 >= iso
+			for _, k := range exits {
+				if joined {
+					link(k, (k+1)%4)
+				} else {
+					link(k, (k+3)%4)
+				}
+			}
+		}
+	}
+
+	var rings [][]Po2
+	for _, start := range order {
+		seg, ok := starts[start]
+		if !ok {
+			continue
+		}
+
+		var ring []Po2
+		id := start
+		for ok {
+			delete(starts, id)
+			ring = append(ring, seg.point)
+			id = seg.to
+			seg, ok = starts[id]
+		}
+
+		if id == start && len(ring) >= 3 {
+			rings = append(rings, ring)
+		}
+	}
+
+	return rings
+}
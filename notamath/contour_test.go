@@ -0,0 +1,24 @@
+package notamath
+
+import "testing"
+
+func TestMarchingSquaresStaysOnBorder(t *testing.T) {
+	// the further above iso the samples are, the further out the crossings used to drift past the border
+	for _, inside := range []float32{5.5, 10, 1000} {
+		rings := MarchingSquares(3, 2, func(x, y int) float32 { return inside }, 5)
+		if len(rings) != 1 {
+			t.Fatalf("value %v: got %d rings, want 1", inside, len(rings))
+		}
+		for _, p := range rings[0] {
+			if p.X < 0 || p.X > 3 || p.Y < 0 || p.Y > 2 {
+				t.Errorf("value %v: point %v is outside the 3x2 grid", inside, p)
+			}
+			if p.X != 0 && p.X != 3 && p.Y != 0 && p.Y != 2 {
+				t.Errorf("value %v: point %v is not on the border", inside, p)
+			}
+		}
+		if area := SignedArea(rings[0]); area <= 0 {
+			t.Errorf("value %v: ring has area %f, want a counter-clockwise outline", inside, area)
+		}
+	}
+}
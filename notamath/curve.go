@@ -0,0 +1,185 @@
+package notamath
+
+import "sort"
+
+// vector is implemented by Vec2 and Vec3 so curves can be shared between 2D and 3D
+type vector[T any] interface {
+	Add(T) T
+	Sub(T) T
+	Mul(float32) T
+	Dot(T) float32
+	Len() float32
+	Normalize() T
+}
+
+// Curve is a parametric curve over t in [0, 1]
+type Curve[T vector[T]] interface {
+	Point(t float32) T
+	Derivative(t float32) T
+}
+
+// Tangent returns the normalized direction of the curve at t
+func Tangent[T vector[T]](c Curve[T], t float32) T {
+	return c.Derivative(t).Normalize()
+}
+
+// ClosestT returns the parameter of the point on the curve closest to p.
+// The curve is sampled and the best sample is refined, samples <= 0 uses a default of 64
+func ClosestT[T vector[T]](c Curve[T], p T, samples int) float32 {
+	if samples <= 0 {
+		samples = 64
+	}
+
+	dist := func(t float32) float32 {
+		d := c.Point(t).Sub(p)
+		return d.Dot(d)
+	}
+
+	best, bestD := float32(0), dist(0)
+	for i := 1; i <= samples; i++ {
+		t := float32(i) / float32(samples)
+		if d := dist(t); d < bestD {
+			best, bestD = t, d
+		}
+	}
+
+	// golden section search around the best sample
+	step := 1 / float32(samples)
+	lo, hi := max(best-step, 0), min(best+step, 1)
+	const invPhi = 0.618034
+	for i := 0; i < 24; i++ {
+		a := hi - (hi-lo)*invPhi
+		b := lo + (hi-lo)*invPhi
+		if dist(a) < dist(b) {
+			hi = b
+		} else {
+			lo = a
+		}
+	}
+
+	t := (lo + hi) / 2
+	if dist(t) < bestD {
+		return t
+	}
+	return best
+}
+
+// ClosestPoint returns the point on the curve closest to p
+func ClosestPoint[T vector[T]](c Curve[T], p T, samples int) T {
+	return c.Point(ClosestT(c, p, samples))
+}
+
+// Flatten turns the curve into a polyline whose points deviate from the curve by at most tolerance
+func Flatten[T vector[T]](c Curve[T], tolerance float32) []T {
+	const startPieces = 8
+	const maxDepth = 10
+
+	out := []T{c.Point(0)}
+	prevT, prev := float32(0), out[0]
+	for i := 1; i <= startPieces; i++ {
+		t := float32(i) / startPieces
+		p := c.Point(t)
+		out = flattenRange(c, prevT, t, prev, p, tolerance, maxDepth, out)
+		prevT, prev = t, p
+	}
+	return out
+}
+
+func flattenRange[T vector[T]](c Curve[T], t0, t1 float32, p0, p1 T, tolerance float32, depth int, out []T) []T {
+	tm := (t0 + t1) / 2
+	pm := c.Point(tm)
+
+	if depth > 0 && distanceToChord(pm, p0, p1) > tolerance {
+		out = flattenRange(c, t0, tm, p0, pm, tolerance, depth-1, out)
+		return flattenRange(c, tm, t1, pm, p1, tolerance, depth-1, out)
+	}
+	return append(out, p1)
+}
+
+func distanceToChord[T vector[T]](p, a, b T) float32 {
+	ab := b.Sub(a)
+	d := ab.Dot(ab)
+	if d == 0 {
+		return p.Sub(a).Len()
+	}
+	t := clamp(p.Sub(a).Dot(ab)/d, 0, 1)
+	return p.Sub(a.Add(ab.Mul(t))).Len()
+}
+
+// FlattenPo2 flattens a 2D curve into points ready for a polygon or line strip
+func FlattenPo2(c Curve[Vec2], tolerance float32) []Po2 {
+	vs := Flatten(c, tolerance)
+	out := make([]Po2, len(vs))
+	for i, v := range vs {
+		out[i] = Po2(v)
+	}
+	return out
+}
+
+func FlattenPo3(c Curve[Vec3], tolerance float32) []Po3 {
+	vs := Flatten(c, tolerance)
+	out := make([]Po3, len(vs))
+	for i, v := range vs {
+		out[i] = Po3(v)
+	}
+	return out
+}
+
+// ArcLength maps distance along a curve back to its parameter, used for constant speed motion
+type ArcLength[T vector[T]] struct {
+	curve   Curve[T]
+	ts      []float32
+	lengths []float32 // cumulative length at each ts
+}
+
+// NewArcLength measures the curve with the given number of chord samples, samples <= 0 uses a default of 128
+func NewArcLength[T vector[T]](c Curve[T], samples int) *ArcLength[T] {
+	if samples <= 0 {
+		samples = 128
+	}
+
+	a := &ArcLength[T]{
+		curve:   c,
+		ts:      make([]float32, samples+1),
+		lengths: make([]float32, samples+1),
+	}
+
+	prev := c.Point(0)
+	for i := 1; i <= samples; i++ {
+		t := float32(i) / float32(samples)
+		p := c.Point(t)
+		a.ts[i] = t
+		a.lengths[i] = a.lengths[i-1] + p.Sub(prev).Len()
+		prev = p
+	}
+	return a
+}
+
+func (a *ArcLength[T]) Length() float32 {
+	return a.lengths[len(a.lengths)-1]
+}
+
+// TAtDistance returns the curve parameter that lies d units along the curve
+func (a *ArcLength[T]) TAtDistance(d float32) float32 {
+	total := a.Length()
+	if d <= 0 || total == 0 {
+		return 0
+	}
+	if d >= total {
+		return 1
+	}
+
+	i := sort.Search(len(a.lengths), func(i int) bool { return a.lengths[i] >= d })
+	l0, l1 := a.lengths[i-1], a.lengths[i]
+	f := (d - l0) / (l1 - l0)
+	return a.ts[i-1] + (a.ts[i]-a.ts[i-1])*f
+}
+
+func (a *ArcLength[T]) PointAtDistance(d float32) T {
+	return a.curve.Point(a.TAtDistance(d))
+}
+
+// PointAtFraction returns the point at a fraction in [0, 1] of the total length, moving f at a constant rate moves at constant speed
+func (a *ArcLength[T]) PointAtFraction(f float32) T {
+	return a.PointAtDistance(f * a.Length())
+}
@@ -0,0 +1,442 @@
+package notamath
+
+import (
+	"fmt"
+	"math"
+)
+
+// Triangulation indexes into Points, every three entries of Triangles form a counter-clockwise triangle
+type Triangulation struct {
+	Points    []Po2
+	Triangles []int
+}
+
+// Len returns the number of triangles
+func (t Triangulation) Len() int {
+	return len(t.Triangles) / 3
+}
+
+// Triangle returns the corners of triangle i
+func (t Triangulation) Triangle(i int) (Po2, Po2, Po2) {
+	return t.Points[t.Triangles[i*3]], t.Points[t.Triangles[i*3+1]], t.Points[t.Triangles[i*3+2]]
+}
+
+// Flat expands the indices into a flat triangle list like Triangulate returns
+func (t Triangulation) Flat() []Po2 {
+	out := make([]Po2, len(t.Triangles))
+	for i, j := range t.Triangles {
+		out[i] = t.Points[j]
+	}
+	return out
+}
+
+// Edges returns every edge once, the smaller index first
+func (t Triangulation) Edges() [][2]int {
+	seen := make(map[[2]int]bool)
+	var edges [][2]int
+	for i := 0; i+2 < len(t.Triangles); i += 3 {
+		for k := 0; k < 3; k++ {
+			a, b := t.Triangles[i+k], t.Triangles[i+(k+1)%3]
+			e := [2]int{min(a, b), max(a, b)}
+			if !seen[e] {
+				seen[e] = true
+				edges = append(edges, e)
+			}
+		}
+	}
+	return edges
+}
+
+type delaunayTri struct {
+	v    [3]int
+	dead bool
+}
+
+// Delaunay triangulates a point set with Bowyer-Watson, no triangle's circumcircle contains another point.
+// Duplicate points are kept in Points but left out of the triangles
+func Delaunay(points []Po2) Triangulation {
+	result := Triangulation{Points: points}
+	if len(points) < 3 {
+		return result
+	}
+
+	n := len(points)
+	pts := make([]bpoint, n, n+3)
+	minP := bpoint{math.Inf(1), math.Inf(1)}
+	maxP := bpoint{math.Inf(-1), math.Inf(-1)}
+	for i, p := range points {
+		pts[i] = bpoint{float64(p.X), float64(p.Y)}
+		minP = bpoint{math.Min(minP.x, pts[i].x), math.Min(minP.y, pts[i].y)}
+		maxP = bpoint{math.Max(maxP.x, pts[i].x), math.Max(maxP.y, pts[i].y)}
+	}
+
+	// a super triangle far enough out that it barely bends the hull
+	size := math.Max(math.Max(maxP.x-minP.x, maxP.y-minP.y), 1e-9) * 1e4
+	cx, cy := (minP.x+maxP.x)/2, (minP.y+maxP.y)/2
+	pts = append(pts,
+		bpoint{cx - size, cy - size},
+		bpoint{cx + size, cy - size},
+		bpoint{cx, cy + size},
+	)
+
+	tris := []delaunayTri{{v: [3]int{n, n + 1, n + 2}}}
+	seen := make(map[Po2]bool, n)
+
+	for i := 0; i < n; i++ {
+		if seen[points[i]] {
+			continue
+		}
+		seen[points[i]] = true
+		p := pts[i]
+
+		// triangles whose circumcircle holds p form the cavity, its outline is fanned to p
+		edges := make(map[[2]int]int)
+		var order [][2]int
+		for t := range tris {
+			tri := &tris[t]
+			if tri.dead || !inCircumcircle(pts[tri.v[0]], pts[tri.v[1]], pts[tri.v[2]], p) {
+				continue
+			}
+			tri.dead = true
+			for k := 0; k < 3; k++ {
+				e := [2]int{tri.v[k], tri.v[(k+1)%3]}
+				if _, ok := edges[[2]int{e[1], e[0]}]; ok {
+					delete(edges, [2]int{e[1], e[0]})
+					continue
+				}
+				edges[e] = len(order)
+				order = append(order, e)
+			}
+		}
+
+		for _, e := range order {
+			if _, ok := edges[e]; ok {
+				tris = append(tris, delaunayTri{v: [3]int{e[0], e[1], i}})
+			}
+		}
+
+		if len(tris) > 64 && i%64 == 0 {
+			tris = compactTris(tris)
+		}
+	}
+
+	for _, t := range tris {
+		if t.dead || t.v[0] >= n || t.v[1] >= n || t.v[2] >= n {
+			continue
+		}
+		result.Triangles = append(result.Triangles, t.v[0], t.v[1], t.v[2])
+	}
+	return result
+}
+
+func compactTris(tris []delaunayTri) []delaunayTri {
+	live := tris[:0]
+	for _, t := range tris {
+		if !t.dead {
+			live = append(live, t)
+		}
+	}
+	return live
+}
+
+// inCircumcircle reports whether p lies strictly inside the circumcircle of the counter-clockwise triangle abc
+func inCircumcircle(a, b, c, p bpoint) bool {
+	ax, ay := a.x-p.x, a.y-p.y
+	bx, by := b.x-p.x, b.y-p.y
+	cx, cy := c.x-p.x, c.y-p.y
+
+	det := (ax*ax+ay*ay)*(bx*cy-cx*by) -
+		(bx*bx+by*by)*(ax*cy-cx*ay) +
+		(cx*cx+cy*cy)*(ax*by-bx*ay)
+	return det > 0
+}
+
+// ConstrainedDelaunay triangulates points like Delaunay but forces every edge in edges (pairs of point indices)
+// into the result, flipping crossing edges out of the way. Edges must not cross each other, an edge that could not
+// be inserted is reported and left out, the rest of the triangulation is still returned
+func ConstrainedDelaunay(points []Po2, edges [][2]int) (Triangulation, error) {
+	t := Delaunay(points)
+	if len(t.Triangles) == 0 {
+		return t, nil
+	}
+
+	m := newDelaunayMesh(t)
+	var err error
+	for _, e := range edges {
+		if !m.insertConstraint(e[0], e[1]) && err == nil {
+			err = fmt.Errorf("constraint edge %d-%d could not be inserted", e[0], e[1])
+		}
+	}
+	m.legalize()
+
+	t.Triangles = m.triangles()
+	return t, err
+}
+
+// DelaunayShape triangulates an outline with holes, keeping its edges and dropping triangles outside the shape.
+// It fails like ConstrainedDelaunay when the rings cross each other
+func DelaunayShape(s PolygonShape) (Triangulation, error) {
+	var points []Po2
+	var edges [][2]int
+	rings := append([][]Po2{s.Outer}, s.Holes...)
+
+	for _, ring := range rings {
+		start := len(points)
+		points = append(points, ring...)
+		for i := range ring {
+			edges = append(edges, [2]int{start + i, start + (i+1)%len(ring)})
+		}
+	}
+
+	t, err := ConstrainedDelaunay(points, edges)
+
+	// the mesh covers the convex hull, keep what lies inside the outline and outside the holes
+	kept := t.Triangles[:0]
+	for i := 0; i+2 < len(t.Triangles); i += 3 {
+		a, b, c := t.Points[t.Triangles[i]], t.Points[t.Triangles[i+1]], t.Points[t.Triangles[i+2]]
+		centroid := Po2{(a.X + b.X + c.X) / 3, (a.Y + b.Y + c.Y) / 3}
+
+		inside := pointInRing(centroid, s.Outer)
+		for _, h := range s.Holes {
+			if pointInRing(centroid, h) {
+				inside = false
+			}
+		}
+		if inside {
+			kept = append(kept, t.Triangles[i], t.Triangles[i+1], t.Triangles[i+2])
+		}
+	}
+	t.Triangles = kept
+	return t, err
+}
+
+// delaunayMesh keeps triangles with a directed edge lookup so edges can be flipped
+type delaunayMesh struct {
+	pts   []bpoint
+	tris  [][3]int
+	edges map[[2]int]int
+	fixed map[[2]int]bool
+}
+
+func newDelaunayMesh(t Triangulation) *delaunayMesh {
+	m := &delaunayMesh{
+		pts:   make([]bpoint, len(t.Points)),
+		edges: make(map[[2]int]int),
+		fixed: make(map[[2]int]bool),
+	}
+	for i, p := range t.Points {
+		m.pts[i] = bpoint{float64(p.X), float64(p.Y)}
+	}
+	for i := 0; i+2 < len(t.Triangles); i += 3 {
+		m.setTri(len(m.tris), [3]int{t.Triangles[i], t.Triangles[i+1], t.Triangles[i+2]}, true)
+	}
+	return m
+}
+
+func (m *delaunayMesh) setTri(i int, v [3]int, add bool) {
+	if add {
+		m.tris = append(m.tris, v)
+	} else {
+		m.tris[i] = v
+	}
+	for k := 0; k < 3; k++ {
+		m.edges[[2]int{v[k], v[(k+1)%3]}] = i
+	}
+}
+
+func (m *delaunayMesh) triangles() []int {
+	out := make([]int, 0, len(m.tris)*3)
+	for _, t := range m.tris {
+		out = append(out, t[0], t[1], t[2])
+	}
+	return out
+}
+
+// opposite returns the corner of triangle t that is not on edge a-b
+func (m *delaunayMesh) opposite(t, a, b int) int {
+	for _, v := range m.tris[t] {
+		if v != a && v != b {
+			return v
+		}
+	}
+	return -1
+}
+
+// flip replaces the triangles on both sides of edge a-b with the ones on the other diagonal, returning it
+func (m *delaunayMesh) flip(a, b int) (int, int, bool) {
+	t1, ok1 := m.edges[[2]int{a, b}]
+	t2, ok2 := m.edges[[2]int{b, a}]
+	if !ok1 || !ok2 {
+		return 0, 0, false
+	}
+	c := m.opposite(t1, a, b)
+	d := m.opposite(t2, b, a)
+
+	delete(m.edges, [2]int{a, b})
+	delete(m.edges, [2]int{b, a})
+	m.setTri(t1, [3]int{a, d, c}, false)
+	m.setTri(t2, [3]int{d, b, c}, false)
+	return c, d, true
+}
+
+func (m *delaunayMesh) hasEdge(a, b int) bool {
+	_, ok := m.edges[[2]int{a, b}]
+	if !ok {
+		_, ok = m.edges[[2]int{b, a}]
+	}
+	return ok
+}
+
+// edgeList returns every edge once, the smaller index first, in triangle order so flips happen in the same order
+// every run
+func (m *delaunayMesh) edgeList() [][2]int {
+	var out [][2]int
+	for _, t := range m.tris {
+		for k := 0; k < 3; k++ {
+			if a, b := t[k], t[(k+1)%3]; a < b || !m.hasDirected(b, a) {
+				out = append(out, [2]int{min(a, b), max(a, b)})
+			}
+		}
+	}
+	return out
+}
+
+func (m *delaunayMesh) hasDirected(a, b int) bool {
+	_, ok := m.edges[[2]int{a, b}]
+	return ok
+}
+
+// insertConstraint forces edge a-b into the mesh and reports whether it is there afterwards
+func (m *delaunayMesh) insertConstraint(a, b int) bool {
+	if a == b || m.pts[a] == m.pts[b] {
+		return true
+	}
+
+	// a point lying on the constraint splits it in two
+	pa, pb := m.pts[a], m.pts[b]
+	d := pb.sub(pa)
+	l := d.dot(d)
+	for i, p := range m.pts {
+		if i == a || i == b || p == pa || p == pb {
+			continue
+		}
+		t := p.sub(pa).dot(d) / l
+		if t > 0 && t < 1 && math.Abs(d.cross(p.sub(pa))) <= 1e-12*l {
+			if _, used := m.vertexTri(i); used {
+				ok := m.insertConstraint(a, i)
+				return m.insertConstraint(i, b) && ok
+			}
+		}
+	}
+
+	if m.hasEdge(a, b) {
+		m.fix(a, b)
+		return true
+	}
+
+	// Sloan's method: flip crossing edges until none are left, legalize restores the Delaunay property afterwards
+	var crossing [][2]int
+	for _, e := range m.edgeList() {
+		if m.crosses(e[0], e[1], a, b) {
+			if m.fixed[e] {
+				// flipping it would drop an earlier constraint
+				return false
+			}
+			crossing = append(crossing, e)
+		}
+	}
+
+	for guard := 0; len(crossing) > 0 && guard < 64*len(m.tris); guard++ {
+		e := crossing[0]
+		crossing = crossing[1:]
+
+		t1, ok := m.edges[[2]int{e[0], e[1]}]
+		t2, ok2 := m.edges[[2]int{e[1], e[0]}]
+		if !ok || !ok2 {
+			continue
+		}
+		c := m.opposite(t1, e[0], e[1])
+		dd := m.opposite(t2, e[1], e[0])
+
+		if !m.convexQuad(e[0], dd, e[1], c) {
+			crossing = append(crossing, e)
+			continue
+		}
+
+		m.flip(e[0], e[1])
+		if m.crosses(c, dd, a, b) {
+			crossing = append(crossing, [2]int{c, dd})
+		}
+	}
+
+	// the guard ran out with crossing edges left, which happens when constraints cross each other
+	if !m.hasEdge(a, b) {
+		return false
+	}
+	m.fix(a, b)
+	return true
+}
+
+// legalize flips every free edge that fails the circumcircle test until none do
+func (m *delaunayMesh) legalize() {
+	for pass := 0; pass < len(m.tris)+1; pass++ {
+		changed := false
+		for _, e := range m.edgeList() {
+			if m.fixed[e] {
+				continue
+			}
+			t1, ok := m.edges[[2]int{e[0], e[1]}]
+			t2, ok2 := m.edges[[2]int{e[1], e[0]}]
+			if !ok || !ok2 {
+				continue
+			}
+			c := m.opposite(t1, e[0], e[1])
+			d := m.opposite(t2, e[1], e[0])
+			if inCircumcircle(m.pts[e[0]], m.pts[e[1]], m.pts[c], m.pts[d]) && m.convexQuad(e[0], d, e[1], c) {
+				m.flip(e[0], e[1])
+				changed = true
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+}
+
+func (m *delaunayMesh) fix(a, b int) {
+	m.fixed[[2]int{a, b}] = true
+	m.fixed[[2]int{b, a}] = true
+}
+
+func (m *delaunayMesh) vertexTri(v int) (int, bool) {
+	for i, t := range m.tris {
+		if t[0] == v || t[1] == v || t[2] == v {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// crosses reports whether edge p-q properly crosses a-b, sharing an endpoint does not count
+func (m *delaunayMesh) crosses(p, q, a, b int) bool {
+	if p == a || p == b || q == a || q == b {
+		return false
+	}
+	pp, pq, pa, pb := m.pts[p], m.pts[q], m.pts[a], m.pts[b]
+	o1 := pq.sub(pp).cross(pa.sub(pp))
+	o2 := pq.sub(pp).cross(pb.sub(pp))
+	o3 := pb.sub(pa).cross(pp.sub(pa))
+	o4 := pb.sub(pa).cross(pq.sub(pa))
+	return o1*o2 < 0 && o3*o4 < 0
+}
+
+// convexQuad reports whether the counter-clockwise quad abcd is strictly convex
+func (m *delaunayMesh) convexQuad(a, b, c, d int) bool {
+	q := [4]bpoint{m.pts[a], m.pts[b], m.pts[c], m.pts[d]}
+	for i := 0; i < 4; i++ {
+		if q[(i+1)%4].sub(q[i]).cross(q[(i+2)%4].sub(q[(i+1)%4])) <= 0 {
+			return false
+		}
+	}
+	return true
+}
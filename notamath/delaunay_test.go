@@ -0,0 +1,54 @@
+package notamath
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// circlePoints are all cocircular, so the Delaunay triangulation is not unique and a constraint through the middle
+// crosses many edges
+func circlePoints(n int) []Po2 {
+	pts := make([]Po2, n)
+	for i := range pts {
+		a := float64(i) * 2 * math.Pi / float64(n)
+		pts[i] = Po2{float32(math.Cos(a)), float32(math.Sin(a))}
+	}
+	return pts
+}
+
+func TestConstrainedDelaunayDeterministic(t *testing.T) {
+	pts := circlePoints(24)
+	edges := [][2]int{{0, 12}, {3, 9}, {15, 21}}
+
+	first, err := ConstrainedDelaunay(pts, edges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range edges {
+		if !slices.Contains(first.Edges(), [2]int{min(e[0], e[1]), max(e[0], e[1])}) {
+			t.Fatalf("constraint %v missing", e)
+		}
+	}
+	for range 20 {
+		next, err := ConstrainedDelaunay(pts, edges)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(next.Triangles, first.Triangles) {
+			t.Fatalf("triangles differ between runs:\n%v\n%v", first.Triangles, next.Triangles)
+		}
+	}
+}
+
+func TestConstrainedDelaunayCrossingEdges(t *testing.T) {
+	pts := []Po2{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {1, 2}, {3, 2}}
+	// 0-2 and 1-3 are both diagonals of the square, only one of them fits
+	tri, err := ConstrainedDelaunay(pts, [][2]int{{0, 2}, {1, 3}})
+	if err == nil {
+		t.Fatal("crossing constraints should be reported")
+	}
+	if tri.Len() == 0 {
+		t.Fatal("the triangulation should still be returned")
+	}
+}
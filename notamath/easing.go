@@ -0,0 +1,166 @@
+package notamath
+
+import "math"
+
+// EaseFunc maps linear progress in [0, 1] to eased progress, 0 and 1 map to themselves
+// but the curve may overshoot in between (Back, Elastic)
+type EaseFunc func(t float32) float32
+
+func Linear(t float32) float32 { return t }
+
+func EaseInQuad(t float32) float32    { return t * t }
+func EaseOutQuad(t float32) float32   { return 1 - (1-t)*(1-t) }
+func EaseInOutQuad(t float32) float32 { return inOut(EaseInQuad, t) }
+
+func EaseInCubic(t float32) float32    { return t * t * t }
+func EaseOutCubic(t float32) float32   { return outOf(EaseInCubic, t) }
+func EaseInOutCubic(t float32) float32 { return inOut(EaseInCubic, t) }
+
+func EaseInQuart(t float32) float32    { return t * t * t * t }
+func EaseOutQuart(t float32) float32   { return outOf(EaseInQuart, t) }
+func EaseInOutQuart(t float32) float32 { return inOut(EaseInQuart, t) }
+
+func EaseInQuint(t float32) float32    { return t * t * t * t * t }
+func EaseOutQuint(t float32) float32   { return outOf(EaseInQuint, t) }
+func EaseInOutQuint(t float32) float32 { return inOut(EaseInQuint, t) }
+
+func EaseInSine(t float32) float32 {
+	return 1 - float32(math.Cos(float64(t)*math.Pi/2))
+}
+func EaseOutSine(t float32) float32 {
+	return float32(math.Sin(float64(t) * math.Pi / 2))
+}
+func EaseInOutSine(t float32) float32 {
+	return -(float32(math.Cos(float64(t)*math.Pi)) - 1) / 2
+}
+
+func EaseInExpo(t float32) float32 {
+	if t <= 0 {
+		return 0
+	}
+	return float32(math.Pow(2, 10*float64(t)-10))
+}
+func EaseOutExpo(t float32) float32   { return outOf(EaseInExpo, t) }
+func EaseInOutExpo(t float32) float32 { return inOut(EaseInExpo, t) }
+
+func EaseInCirc(t float32) float32 {
+	return 1 - float32(math.Sqrt(float64(1-min(t*t, 1))))
+}
+func EaseOutCirc(t float32) float32   { return outOf(EaseInCirc, t) }
+func EaseInOutCirc(t float32) float32 { return inOut(EaseInCirc, t) }
+
+// back easings overshoot by about 10%
+const backOvershoot = 1.70158
+
+func EaseInBack(t float32) float32 {
+	return t * t * ((backOvershoot+1)*t - backOvershoot)
+}
+func EaseOutBack(t float32) float32   { return outOf(EaseInBack, t) }
+func EaseInOutBack(t float32) float32 { return inOut(EaseInBack, t) }
+
+func EaseInElastic(t float32) float32 {
+	if t <= 0 || t >= 1 {
+		return clamp(t, 0, 1)
+	}
+	const c = 2 * math.Pi / 3
+	ft := float64(t)
+	return -float32(math.Pow(2, 10*ft-10) * math.Sin((ft*10-10.75)*c))
+}
+func EaseOutElastic(t float32) float32   { return outOf(EaseInElastic, t) }
+func EaseInOutElastic(t float32) float32 { return inOut(EaseInElastic, t) }
+
+func EaseOutBounce(t float32) float32 {
+	const n, d = 7.5625, 2.75
+
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+func EaseInBounce(t float32) float32    { return outOf(EaseOutBounce, t) }
+func EaseInOutBounce(t float32) float32 { return inOut(EaseInBounce, t) }
+
+// outOf mirrors an ease-in curve into the matching ease-out (and the other way round)
+func outOf(f EaseFunc, t float32) float32 {
+	return 1 - f(1-t)
+}
+
+// inOut runs the ease-in curve on the first half and its mirror on the second half
+func inOut(f EaseFunc, t float32) float32 {
+	if t < 0.5 {
+		return f(2*t) / 2
+	}
+	return 1 - f(2-2*t)/2
+}
+
+// CubicBezier returns a CSS style timing curve through (0, 0), (x1, y1), (x2, y2) and (1, 1).
+// x1 and x2 are clamped to [0, 1] so the curve stays a function of time
+func CubicBezier(x1, y1, x2, y2 float32) EaseFunc {
+	x1 = clamp(x1, 0, 1)
+	x2 = clamp(x2, 0, 1)
+
+	// polynomial coefficients of each axis
+	cx := 3 * x1
+	bx := 3*(x2-x1) - cx
+	ax := 1 - cx - bx
+	cy := 3 * y1
+	by := 3*(y2-y1) - cy
+	ay := 1 - cy - by
+
+	sampleX := func(s float32) float32 { return ((ax*s+bx)*s + cx) * s }
+	sampleY := func(s float32) float32 { return ((ay*s+by)*s + cy) * s }
+	slopeX := func(s float32) float32 { return (3*ax*s+2*bx)*s + cx }
+
+	return func(t float32) float32 {
+		if t <= 0 || t >= 1 {
+			return clamp(t, 0, 1)
+		}
+
+		// newton iterations are fast but can fail on flat slopes, fall back to bisection
+		s := t
+		for i := 0; i < 8; i++ {
+			dx := sampleX(s) - t
+			if absf(dx) < 1e-6 {
+				return sampleY(s)
+			}
+			d := slopeX(s)
+			if absf(d) < 1e-6 {
+				break
+			}
+			s -= dx / d
+		}
+
+		lo, hi := float32(0), float32(1)
+		s = t
+		for i := 0; i < 32; i++ {
+			x := sampleX(s)
+			if absf(x-t) < 1e-6 {
+				break
+			}
+			if x < t {
+				lo = s
+			} else {
+				hi = s
+			}
+			s = (lo + hi) / 2
+		}
+		return sampleY(s)
+	}
+}
+
+// common CSS curves
+var (
+	Ease      = CubicBezier(0.25, 0.1, 0.25, 1)
+	EaseIn    = CubicBezier(0.42, 0, 1, 1)
+	EaseOut   = CubicBezier(0, 0, 0.58, 1)
+	EaseInOut = CubicBezier(0.42, 0, 0.58, 1)
+)
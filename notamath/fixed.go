@@ -0,0 +1,237 @@
+package notamath
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Fixed is a signed fixed point number with 16 fractional bits (Q48.16). Unlike float32 every operation
+// gives bit identical results on every compiler and CPU, which lockstep simulations need.
+// Add and subtract with the plain + and - operators. Mul, Div and Sqrt compute in 128 bits, Mul and Div saturate
+// at FixedMax when the result does not fit
+type Fixed int64
+
+const (
+	fixedShift = 16
+
+	FixedOne     Fixed = 1 << fixedShift
+	FixedHalf    Fixed = FixedOne / 2
+	FixedPi      Fixed = 205887
+	FixedHalfPi  Fixed = 102944
+	FixedTwoPi   Fixed = 411775
+	FixedEpsilon Fixed = 1
+	FixedMax     Fixed = math.MaxInt64
+)
+
+func FixedFromInt(v int) Fixed {
+	return Fixed(v) << fixedShift
+}
+
+// FixedFromFloat converts a float, only use it for setup data since the rounding of the source float is not portable
+func FixedFromFloat(v float32) Fixed {
+	if v < 0 {
+		return Fixed(v*float32(FixedOne) - 0.5)
+	}
+	return Fixed(v*float32(FixedOne) + 0.5)
+}
+
+// FixedFromRatio returns num / den without going through floats
+func FixedFromRatio(num, den int) Fixed {
+	return FixedFromInt(num).Div(FixedFromInt(den))
+}
+
+func (f Fixed) Float32() float32 {
+	return float32(f) / float32(FixedOne)
+}
+
+// Int truncates towards negative infinity
+func (f Fixed) Int() int {
+	return int(f >> fixedShift)
+}
+
+// Mul multiplies, rounding towards negative infinity. Products that do not fit saturate at FixedMax with the sign
+// of the result
+func (f Fixed) Mul(o Fixed) Fixed {
+	neg := (f < 0) != (o < 0)
+	hi, lo := bits.Mul64(f.magnitude(), o.magnitude())
+	if neg {
+		// round the magnitude up so the negated result still rounds down
+		var carry uint64
+		lo, carry = bits.Add64(lo, uint64(FixedOne-1), 0)
+		hi += carry
+	}
+	return fixedSaturate(hi>>fixedShift, hi<<(64-fixedShift)|lo>>fixedShift, neg)
+}
+
+// Div divides, rounding towards zero. Dividing by zero or quotients that do not fit saturate at FixedMax with the
+// sign of the result
+func (f Fixed) Div(o Fixed) Fixed {
+	neg := (f < 0) != (o < 0)
+	if o == 0 {
+		return fixedSaturate(1, 0, f < 0)
+	}
+	a, b := f.magnitude(), o.magnitude()
+	hi, lo := a>>(64-fixedShift), a<<fixedShift
+	if hi >= b {
+		return fixedSaturate(1, 0, neg)
+	}
+	q, _ := bits.Div64(hi, lo, b)
+	return fixedSaturate(0, q, neg)
+}
+
+// magnitude is |f| as an unsigned number, so the smallest Fixed has one too
+func (f Fixed) magnitude() uint64 {
+	if f < 0 {
+		return -uint64(f)
+	}
+	return uint64(f)
+}
+
+// fixedSaturate applies the sign to the 128 bit magnitude hi:mag, clamping it to FixedMax
+func fixedSaturate(hi, mag uint64, neg bool) Fixed {
+	if hi != 0 || mag > uint64(FixedMax) {
+		mag = uint64(FixedMax)
+	}
+	if neg {
+		return -Fixed(mag)
+	}
+	return Fixed(mag)
+}
+
+func (f Fixed) Abs() Fixed {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+func (f Fixed) Floor() Fixed {
+	return f &^ (FixedOne - 1)
+}
+
+func (f Fixed) Ceil() Fixed {
+	return (f + FixedOne - 1).Floor()
+}
+
+func (f Fixed) Clamp(lo, hi Fixed) Fixed {
+	return min(max(f, lo), hi)
+}
+
+// Lerp interpolates towards to, t is in Fixed as well
+func (f Fixed) Lerp(to Fixed, t Fixed) Fixed {
+	return f + (to - f).Mul(t)
+}
+
+// Sqrt returns the square root, negative inputs return 0
+func (f Fixed) Sqrt() Fixed {
+	if f <= 0 {
+		return 0
+	}
+	// the radicand needs 16 more bits than a Fixed has, so it is taken in 128 bits
+	return Fixed(isqrt(uint64(f)>>(64-fixedShift), uint64(f)<<fixedShift))
+}
+
+// isqrt is the integer square root of hi:lo, rounded down. hi:lo must be below 2^126
+func isqrt(hi, lo uint64) uint64 {
+	n := bits.Len64(lo)
+	if hi != 0 {
+		n = 64 + bits.Len64(hi)
+	}
+	if n == 0 {
+		return 0
+	}
+
+	// start from a power of two above the root and refine with newton steps, staying above the root keeps the
+	// quotient below 2^64
+	r := uint64(1) << ((n + 1) / 2)
+	for {
+		q, _ := bits.Div64(hi, lo, r)
+		next := (r + q) / 2
+		if next >= r {
+			return r
+		}
+		r = next
+	}
+}
+
+// Sin uses a quarter wave table with linear interpolation, the error stays within a few units of the last place
+func (f Fixed) Sin() Fixed {
+	a := int64(f) % int64(FixedTwoPi)
+	if a < 0 {
+		a += int64(FixedTwoPi)
+	}
+
+	// position in 1/65536ths of the 1024 table steps of a full turn
+	u := a * 1024 << fixedShift / int64(FixedTwoPi)
+	step := int(u >> fixedShift)
+	frac := u & int64(FixedOne-1)
+
+	s0 := sinStep(step)
+	s1 := sinStep(step + 1)
+	return Fixed(s0 + (s1-s0)*frac>>fixedShift)
+}
+
+func (f Fixed) Cos() Fixed {
+	return (f + FixedHalfPi).Sin()
+}
+
+// sinStep returns sin of step / 1024 turns from the quarter wave table
+func sinStep(step int) int64 {
+	step &= 1023
+	i := step & 255
+
+	switch step >> 8 {
+	case 0:
+		return int64(fixedSinTable[i])
+	case 1:
+		return int64(fixedSinTable[256-i])
+	case 2:
+		return -int64(fixedSinTable[i])
+	default:
+		return -int64(fixedSinTable[256-i])
+	}
+}
+
+// FixedAtan2 returns the angle of (x, y) in (-pi, pi]
+func FixedAtan2(y, x Fixed) Fixed {
+	if x == 0 && y == 0 {
+		return 0
+	}
+
+	ax, ay := x.Abs(), y.Abs()
+
+	// atan of the ratio in [0, 1], mirrored into the right octant
+	var a Fixed
+	if ay <= ax {
+		a = fixedAtanUnit(ay.Div(ax))
+	} else {
+		a = FixedHalfPi - fixedAtanUnit(ax.Div(ay))
+	}
+
+	if x < 0 {
+		a = FixedPi - a
+	}
+	if y < 0 {
+		a = -a
+	}
+	return a
+}
+
+// fixedAtanUnit returns atan(r) for r in [0, 1]
+func fixedAtanUnit(r Fixed) Fixed {
+	u := int64(r) * 256
+	step := int(u >> fixedShift)
+	if step >= 256 {
+		return Fixed(fixedAtanTable[256])
+	}
+	frac := u & int64(FixedOne-1)
+
+	a0 := int64(fixedAtanTable[step])
+	a1 := int64(fixedAtanTable[step+1])
+	return Fixed(a0 + (a1-a0)*frac>>fixedShift)
+}
+
+func (f Fixed) String() string {
+	return fmt.Sprintf("Fixed(%f)", f.Float32())
+}
@@ -0,0 +1,110 @@
+package notamath
+
+// FixedMat3 is the fixed point counterpart of Mat3, using the same row-major layout
+type FixedMat3 struct {
+	M [9]Fixed
+}
+
+func FixedMat3Identity() FixedMat3 {
+	return FixedMat3{M: [9]Fixed{
+		FixedOne, 0, 0,
+		0, FixedOne, 0,
+		0, 0, FixedOne,
+	}}
+}
+
+func FixedMat3Translation(t FixedVec2) FixedMat3 {
+	return FixedMat3{M: [9]Fixed{
+		FixedOne, 0, t.X,
+		0, FixedOne, t.Y,
+		0, 0, FixedOne,
+	}}
+}
+
+func FixedMat3Scale(s FixedVec2) FixedMat3 {
+	return FixedMat3{M: [9]Fixed{
+		s.X, 0, 0,
+		0, s.Y, 0,
+		0, 0, FixedOne,
+	}}
+}
+
+func FixedMat3Rotation(rad Fixed) FixedMat3 {
+	c, s := rad.Cos(), rad.Sin()
+	return FixedMat3{M: [9]Fixed{
+		c, -s, 0,
+		s, c, 0,
+		0, 0, FixedOne,
+	}}
+}
+
+// FixedMat3TRS matches Mat3TRS
+func FixedMat3TRS(pos FixedVec2, rot Fixed, scale FixedVec2) FixedMat3 {
+	c, s := rot.Cos(), rot.Sin()
+	return FixedMat3{M: [9]Fixed{
+		c.Mul(scale.X), -s.Mul(scale.Y), pos.X,
+		s.Mul(scale.X), c.Mul(scale.Y), pos.Y,
+		0, 0, FixedOne,
+	}}
+}
+
+func (m FixedMat3) Mul(b FixedMat3) FixedMat3 {
+	var result FixedMat3
+
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			var sum Fixed
+			for k := 0; k < 3; k++ {
+				sum += m.M[row*3+k].Mul(b.M[k*3+col])
+			}
+			result.M[row*3+col] = sum
+		}
+	}
+
+	return result
+}
+
+func (m FixedMat3) TransformPoint(p FixedVec2) FixedVec2 {
+	return FixedVec2{
+		X: m.M[0].Mul(p.X) + m.M[1].Mul(p.Y) + m.M[2],
+		Y: m.M[3].Mul(p.X) + m.M[4].Mul(p.Y) + m.M[5],
+	}
+}
+
+func (m FixedMat3) TransformVector(v FixedVec2) FixedVec2 {
+	return FixedVec2{
+		X: m.M[0].Mul(v.X) + m.M[1].Mul(v.Y),
+		Y: m.M[3].Mul(v.X) + m.M[4].Mul(v.Y),
+	}
+}
+
+func (m FixedMat3) Det() Fixed {
+	return m.M[0].Mul(m.M[4].Mul(m.M[8])-m.M[5].Mul(m.M[7])) -
+		m.M[1].Mul(m.M[3].Mul(m.M[8])-m.M[5].Mul(m.M[6])) +
+		m.M[2].Mul(m.M[3].Mul(m.M[7])-m.M[4].Mul(m.M[6]))
+}
+
+// InverseAffine matches Mat3.InverseAffine
+func (m FixedMat3) InverseAffine() FixedMat3 {
+	a, b, c := m.M[0], m.M[1], m.M[2]
+	d, e, f := m.M[3], m.M[4], m.M[5]
+
+	det := a.Mul(e) - b.Mul(d)
+	if det == 0 {
+		return FixedMat3Identity()
+	}
+
+	return FixedMat3{M: [9]Fixed{
+		e.Div(det), (-b).Div(det), (b.Mul(f) - e.Mul(c)).Div(det),
+		(-d).Div(det), a.Div(det), (d.Mul(c) - a.Mul(f)).Div(det),
+		0, 0, FixedOne,
+	}}
+}
+
+func (m FixedMat3) Mat3() Mat3 {
+	var r Mat3
+	for i, v := range m.M {
+		r.M[i] = v.Float32()
+	}
+	return r
+}
@@ -0,0 +1,138 @@
+package notamath
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// FixedVec2 is the fixed point counterpart of Vec2, it is used for both points and vectors
+type FixedVec2 struct {
+	X, Y Fixed
+}
+
+func FixedVec2FromVec2(v Vec2) FixedVec2 {
+	return FixedVec2{FixedFromFloat(v.X), FixedFromFloat(v.Y)}
+}
+
+func FixedVec2FromPo2(p Po2) FixedVec2 {
+	return FixedVec2{FixedFromFloat(p.X), FixedFromFloat(p.Y)}
+}
+
+func (v FixedVec2) Vec2() Vec2 {
+	return Vec2{v.X.Float32(), v.Y.Float32()}
+}
+
+func (v FixedVec2) Po2() Po2 {
+	return Po2{v.X.Float32(), v.Y.Float32()}
+}
+
+func (v FixedVec2) Add(o FixedVec2) FixedVec2 {
+	return FixedVec2{v.X + o.X, v.Y + o.Y}
+}
+
+func (v FixedVec2) Sub(o FixedVec2) FixedVec2 {
+	return FixedVec2{v.X - o.X, v.Y - o.Y}
+}
+
+func (v FixedVec2) Mul(s Fixed) FixedVec2 {
+	return FixedVec2{v.X.Mul(s), v.Y.Mul(s)}
+}
+
+func (v FixedVec2) Div(s Fixed) FixedVec2 {
+	return FixedVec2{v.X.Div(s), v.Y.Div(s)}
+}
+
+func (v FixedVec2) Neg() FixedVec2 {
+	return FixedVec2{-v.X, -v.Y}
+}
+
+func (v FixedVec2) Dot(o FixedVec2) Fixed {
+	return v.X.Mul(o.X) + v.Y.Mul(o.Y)
+}
+
+func (v FixedVec2) Cross(o FixedVec2) Fixed {
+	return v.X.Mul(o.Y) - v.Y.Mul(o.X)
+}
+
+func (v FixedVec2) LenSquared() Fixed {
+	return v.Dot(v)
+}
+
+func (v FixedVec2) Len() Fixed {
+	return v.LenSquared().Sqrt()
+}
+
+func (v FixedVec2) Distance(o FixedVec2) Fixed {
+	return v.Sub(o).Len()
+}
+
+func (v FixedVec2) DistanceSquared(o FixedVec2) Fixed {
+	return v.Sub(o).LenSquared()
+}
+
+func (v FixedVec2) Normalize() FixedVec2 {
+	l := v.Len()
+	if l == 0 {
+		return FixedVec2{}
+	}
+	return v.Div(l)
+}
+
+func (v FixedVec2) Perp() FixedVec2 {
+	return FixedVec2{-v.Y, v.X}
+}
+
+func (v FixedVec2) Lerp(to FixedVec2, t Fixed) FixedVec2 {
+	return v.Add(to.Sub(v).Mul(t))
+}
+
+func (v FixedVec2) Rotate(rad Fixed) FixedVec2 {
+	c, s := rad.Cos(), rad.Sin()
+	return FixedVec2{
+		v.X.Mul(c) - v.Y.Mul(s),
+		v.X.Mul(s) + v.Y.Mul(c),
+	}
+}
+
+func (v FixedVec2) Angle() Fixed {
+	return FixedAtan2(v.Y, v.X)
+}
+
+// FixedOrient is the fixed point counterpart of Orient
+func FixedOrient(a, b, c FixedVec2) Fixed {
+	return b.Sub(a).Cross(c.Sub(a))
+}
+
+// FixedOrientSign returns the sign of FixedOrient, -1, 0 or 1. FixedOrient drops the low 16 bits of the products
+// so nearly collinear points can come out as 0, this keeps the full 128 bit cross and is exact whenever the
+// coordinate differences fit in a Fixed
+func FixedOrientSign(a, b, c FixedVec2) int {
+	u, v := b.Sub(a), c.Sub(a)
+	hi1, lo1 := mulInt128(int64(u.X), int64(v.Y))
+	hi2, lo2 := mulInt128(int64(u.Y), int64(v.X))
+	lo, borrow := bits.Sub64(lo1, lo2, 0)
+	hi := int64(hi1) - int64(hi2) - int64(borrow)
+	switch {
+	case hi < 0:
+		return -1
+	case hi > 0 || lo > 0:
+		return 1
+	}
+	return 0
+}
+
+// mulInt128 returns the two's complement 128 bit product of a and b
+func mulInt128(a, b int64) (uint64, uint64) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	if a < 0 {
+		hi -= uint64(b)
+	}
+	if b < 0 {
+		hi -= uint64(a)
+	}
+	return hi, lo
+}
+
+func (v FixedVec2) String() string {
+	return fmt.Sprintf("FixedVector2(%f, %f)", v.X.Float32(), v.Y.Float32())
+}
@@ -0,0 +1,93 @@
+package notamath
+
+import "testing"
+
+func TestFixedMulLarge(t *testing.T) {
+	cases := []struct {
+		a, b, want Fixed
+	}{
+		{FixedFromInt(47000), FixedFromInt(47000), FixedFromInt(47000 * 47000)},
+		{FixedFromInt(1 << 23), FixedFromInt(1 << 23), FixedFromInt(1 << 46)},
+		{FixedFromInt(-47000), FixedFromInt(47000), FixedFromInt(-47000 * 47000)},
+		{FixedFromInt(1 << 40), FixedFromInt(1 << 40), FixedMax},
+		{FixedFromInt(-(1 << 40)), FixedFromInt(1 << 40), -FixedMax},
+		{FixedHalf, FixedHalf, FixedOne / 4},
+	}
+	for _, c := range cases {
+		if got := c.a.Mul(c.b); got != c.want {
+			t.Errorf("%v.Mul(%v) = %v (%d), want %v (%d)", c.a, c.b, got, int64(got), c.want, int64(c.want))
+		}
+	}
+}
+
+func TestFixedMulRoundsDown(t *testing.T) {
+	if got := FixedEpsilon.Mul(FixedHalf); got != 0 {
+		t.Errorf("epsilon * 0.5 = %d, want 0", int64(got))
+	}
+	if got := (-FixedEpsilon).Mul(FixedHalf); got != -1 {
+		t.Errorf("-epsilon * 0.5 = %d, want -1", int64(got))
+	}
+}
+
+func TestFixedDiv(t *testing.T) {
+	cases := []struct {
+		a, b, want Fixed
+	}{
+		{FixedFromInt(47000 * 47000), FixedFromInt(47000), FixedFromInt(47000)},
+		{FixedFromInt(1 << 46), FixedFromInt(1 << 23), FixedFromInt(1 << 23)},
+		{FixedFromInt(-7), FixedFromInt(2), -(FixedFromInt(3) + FixedHalf)},
+		{FixedFromInt(1), 0, FixedMax},
+		{FixedFromInt(-1), 0, -FixedMax},
+		{FixedFromInt(1 << 46), FixedEpsilon, FixedMax},
+		{FixedFromInt(1 << 46), -FixedEpsilon, -FixedMax},
+	}
+	for _, c := range cases {
+		if got := c.a.Div(c.b); got != c.want {
+			t.Errorf("%v.Div(%v) = %v (%d), want %v (%d)", c.a, c.b, got, int64(got), c.want, int64(c.want))
+		}
+	}
+}
+
+func TestFixedSqrtLarge(t *testing.T) {
+	cases := []struct {
+		f, want Fixed
+	}{
+		{FixedFromInt(4), FixedFromInt(2)},
+		{FixedOne / 4, FixedHalf},
+		{FixedFromInt(1 << 32), FixedFromInt(1 << 16)},
+		{FixedFromInt(10_000_000_000), FixedFromInt(100_000)},
+		{FixedFromInt(1 << 46), FixedFromInt(1 << 23)},
+		{FixedMax, 777472127993}, // floor(sqrt((2^63 - 1) * 2^16)), the largest root there is
+		{FixedFromInt(-4), 0},
+	}
+	for _, c := range cases {
+		if got := c.f.Sqrt(); got != c.want {
+			t.Errorf("%v.Sqrt() = %v (%d), want %v (%d)", c.f, got, int64(got), c.want, int64(c.want))
+		}
+	}
+
+	if got := (FixedVec2{X: FixedFromInt(70000)}).Len(); got != FixedFromInt(70000) {
+		t.Errorf("len of (70000, 0) = %v, want 70000", got)
+	}
+}
+
+func TestFixedOrientSign(t *testing.T) {
+	big := FixedFromInt(1 << 30)
+	cases := []struct {
+		a, b, c FixedVec2
+		want    int
+	}{
+		// the cross product is a single raw unit squared, FixedOrient rounds it to 0
+		{FixedVec2{}, FixedVec2{X: FixedEpsilon}, FixedVec2{Y: FixedEpsilon}, 1},
+		{FixedVec2{}, FixedVec2{Y: FixedEpsilon}, FixedVec2{X: FixedEpsilon}, -1},
+		{FixedVec2{}, FixedVec2{X: big, Y: big}, FixedVec2{X: 2 * big, Y: 2 * big}, 0},
+		// both products overflow a Fixed, FixedOrient saturates them to the same value
+		{FixedVec2{}, FixedVec2{X: big, Y: big}, FixedVec2{X: 2 * big, Y: 2*big + FixedEpsilon}, 1},
+		{FixedVec2{X: -big}, FixedVec2{X: big, Y: -big}, FixedVec2{X: -big, Y: -FixedEpsilon}, -1},
+	}
+	for i, c := range cases {
+		if got := FixedOrientSign(c.a, c.b, c.c); got != c.want {
+			t.Errorf("case %d: FixedOrientSign = %d, want %d (FixedOrient %d)", i, got, c.want, int64(FixedOrient(c.a, c.b, c.c)))
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package notamath
+
+type Noise2Func func(x, y float32) float32
+type Noise3Func func(x, y, z float32) float32
+
+// Fractal layers octaves of a noise function, each octave multiplies frequency by Lacunarity and amplitude by Gain
+type Fractal struct {
+	Octaves    int
+	Lacunarity float32
+	Gain       float32
+}
+
+// DefaultFractal is a common starting point for terrain
+func DefaultFractal() Fractal {
+	return Fractal{Octaves: 5, Lacunarity: 2, Gain: 0.5}
+}
+
+// FBm2 sums the octaves, normalized back to the range of the source noise
+func (f Fractal) FBm2(n Noise2Func, x, y float32) float32 {
+	return f.sum(func(freq float32) float32 { return n(x*freq, y*freq) })
+}
+
+func (f Fractal) FBm3(n Noise3Func, x, y, z float32) float32 {
+	return f.sum(func(freq float32) float32 { return n(x*freq, y*freq, z*freq) })
+}
+
+// Turbulence2 sums absolute octaves, giving billowy results in [0, 1]
+func (f Fractal) Turbulence2(n Noise2Func, x, y float32) float32 {
+	return f.sum(func(freq float32) float32 { return absf(n(x*freq, y*freq)) })
+}
+
+func (f Fractal) Turbulence3(n Noise3Func, x, y, z float32) float32 {
+	return f.sum(func(freq float32) float32 { return absf(n(x*freq, y*freq, z*freq)) })
+}
+
+// Ridged2 inverts absolute octaves into sharp ridges, results are in [0, 1]
+func (f Fractal) Ridged2(n Noise2Func, x, y float32) float32 {
+	return f.sum(func(freq float32) float32 {
+		r := 1 - absf(n(x*freq, y*freq))
+		return r * r
+	})
+}
+
+func (f Fractal) Ridged3(n Noise3Func, x, y, z float32) float32 {
+	return f.sum(func(freq float32) float32 {
+		r := 1 - absf(n(x*freq, y*freq, z*freq))
+		return r * r
+	})
+}
+
+func (f Fractal) sum(octave func(freq float32) float32) float32 {
+	octaves := max(f.Octaves, 1)
+	freq, amp := float32(1), float32(1)
+
+	var total, norm float32
+	for i := 0; i < octaves; i++ {
+		total += octave(freq) * amp
+		norm += amp
+		freq *= f.Lacunarity
+		amp *= f.Gain
+	}
+	return total / norm
+}
+
+// Warp2 samples n at a position displaced by warp, strength scales the displacement
+func Warp2(n, warp Noise2Func, x, y, strength float32) float32 {
+	// offsets decorrelate the two displacement axes
+	dx := warp(x+5.2, y+1.3)
+	dy := warp(x+9.7, y+2.8)
+	return n(x+dx*strength, y+dy*strength)
+}
+
+func Warp3(n, warp Noise3Func, x, y, z, strength float32) float32 {
+	dx := warp(x+5.2, y+1.3, z+7.1)
+	dy := warp(x+9.7, y+2.8, z+3.4)
+	dz := warp(x+1.9, y+6.5, z+8.3)
+	return n(x+dx*strength, y+dy*strength, z+dz*strength)
+}
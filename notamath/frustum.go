@@ -0,0 +1,104 @@
+package notamath
+
+// Frustum planes point inwards, a point is inside when it is on the positive side of all six
+type Frustum struct {
+	Planes [6]Plane // left, right, bottom, top, near, far
+}
+
+const (
+	FrustumLeft = iota
+	FrustumRight
+	FrustumBottom
+	FrustumTop
+	FrustumNear
+	FrustumFar
+)
+
+// FrustumFromMat4 extracts the planes of a view-projection matrix such as Mat4Perspective(...).Mul(Mat4LookAt(...))
+func FrustumFromMat4(vp Mat4) Frustum {
+	m := vp.M
+	row := func(i int) Vec3 { return Vec3{m[i*4], m[i*4+1], m[i*4+2]} }
+	r0, r1, r2, r3 := row(0), row(1), row(2), row(3)
+	w0, w1, w2, w3 := m[3], m[7], m[11], m[15]
+
+	var f Frustum
+	f.Planes[FrustumLeft] = Plane{r3.Add(r0), w3 + w0}.Normalize()
+	f.Planes[FrustumRight] = Plane{r3.Sub(r0), w3 - w0}.Normalize()
+	f.Planes[FrustumBottom] = Plane{r3.Add(r1), w3 + w1}.Normalize()
+	f.Planes[FrustumTop] = Plane{r3.Sub(r1), w3 - w1}.Normalize()
+	f.Planes[FrustumNear] = Plane{r3.Add(r2), w3 + w2}.Normalize()
+	f.Planes[FrustumFar] = Plane{r3.Sub(r2), w3 - w2}.Normalize()
+	return f
+}
+
+func (f Frustum) ContainsPoint(p Po3) bool {
+	for _, pl := range f.Planes {
+		if pl.SignedDistance(p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsSphere reports whether any part of the sphere may be inside the frustum
+func (f Frustum) IntersectsSphere(s Sphere) bool {
+	for _, pl := range f.Planes {
+		if pl.SignedDistance(s.Center) < -s.Radius {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsSphere reports whether the whole sphere is inside the frustum
+func (f Frustum) ContainsSphere(s Sphere) bool {
+	for _, pl := range f.Planes {
+		if pl.SignedDistance(s.Center) < s.Radius {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsBox reports whether any part of the box may be inside the frustum.
+// It is conservative, large boxes near frustum corners can pass while being outside
+func (f Frustum) IntersectsBox(b Box3) bool {
+	for _, pl := range f.Planes {
+		// corner furthest along the plane normal
+		p := Po3{b.Min.X, b.Min.Y, b.Min.Z}
+		if pl.Normal.X >= 0 {
+			p.X = b.Max.X
+		}
+		if pl.Normal.Y >= 0 {
+			p.Y = b.Max.Y
+		}
+		if pl.Normal.Z >= 0 {
+			p.Z = b.Max.Z
+		}
+		if pl.SignedDistance(p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsBox reports whether the whole box is inside the frustum
+func (f Frustum) ContainsBox(b Box3) bool {
+	for _, pl := range f.Planes {
+		// corner furthest against the plane normal
+		p := Po3{b.Max.X, b.Max.Y, b.Max.Z}
+		if pl.Normal.X >= 0 {
+			p.X = b.Min.X
+		}
+		if pl.Normal.Y >= 0 {
+			p.Y = b.Min.Y
+		}
+		if pl.Normal.Z >= 0 {
+			p.Z = b.Min.Z
+		}
+		if pl.SignedDistance(p) < 0 {
+			return false
+		}
+	}
+	return true
+}
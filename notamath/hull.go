@@ -0,0 +1,369 @@
+package notamath
+
+import "slices"
+
+// ConvexHull2 returns the convex hull of points in counter-clockwise order using Andrew's monotone chain.
+// Points lying on a hull edge are dropped unless keepCollinear is set, duplicates are always dropped
+func ConvexHull2(points []Po2, keepCollinear bool) []Po2 {
+	idx := convexHull2Indices(points, keepCollinear)
+	hull := make([]Po2, len(idx))
+	for i, j := range idx {
+		hull[i] = points[j]
+	}
+	return hull
+}
+
+func convexHull2Indices(points []Po2, keepCollinear bool) []int {
+	order := make([]int, 0, len(points))
+	for i := range points {
+		order = append(order, i)
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		pa, pb := points[a], points[b]
+		switch {
+		case pa.X < pb.X || (pa.X == pb.X && pa.Y < pb.Y):
+			return -1
+		case pa == pb:
+			return 0
+		}
+		return 1
+	})
+	order = slices.CompactFunc(order, func(a, b int) bool { return points[a] == points[b] })
+
+	if len(order) < 3 {
+		return order
+	}
+
+	// pop while the turn is clockwise, or straight when collinear points are dropped
+	turns := func(a, b, c int) bool {
+		o := Orient(points[a], points[b], points[c])
+		if keepCollinear {
+			return o < 0
+		}
+		return o <= 0
+	}
+
+	hull := make([]int, 0, 2*len(order))
+
+	// lower chain
+	for _, i := range order {
+		for len(hull) >= 2 && turns(hull[len(hull)-2], hull[len(hull)-1], i) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, i)
+	}
+
+	// upper chain
+	lower := len(hull) + 1
+	for k := len(order) - 2; k >= 0; k-- {
+		i := order[k]
+		for len(hull) >= lower && turns(hull[len(hull)-2], hull[len(hull)-1], i) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, i)
+	}
+	hull = hull[:len(hull)-1]
+
+	// with collinear points kept, fully collinear input walks the line twice
+	if keepCollinear && len(hull) > len(order) {
+		return order
+	}
+	return hull
+}
+
+// Hull3 is a triangle mesh, every three indices form a triangle wound counter-clockwise seen from outside
+type Hull3 struct {
+	Vertices []Po3
+	Indices  []int
+}
+
+// Triangles expands the indices into a flat triangle list, the layout notagl.Mesh uses
+func (h Hull3) Triangles() []Po3 {
+	tris := make([]Po3, len(h.Indices))
+	for i, j := range h.Indices {
+		tris[i] = h.Vertices[j]
+	}
+	return tris
+}
+
+// Normal returns the outward unit normal of triangle i
+func (h Hull3) Normal(i int) Vec3 {
+	a := h.Vertices[h.Indices[i*3]]
+	b := h.Vertices[h.Indices[i*3+1]]
+	c := h.Vertices[h.Indices[i*3+2]]
+	return b.SubPo(a).Cross(c.SubPo(a)).Normalize()
+}
+
+type hullFace struct {
+	a, b, c int
+	normal  Vec3
+	d       float32
+	outside []int
+	dead    bool
+}
+
+func (f *hullFace) distance(p Po3) float32 {
+	return f.normal.Dot(Vec3(p)) - f.d
+}
+
+// ConvexHull3 builds the convex hull of a point cloud with Quickhull. Points on a face within a small tolerance are
+// left out. Coplanar input gives a flat hull with both sides, collinear input or fewer than three points an empty one.
+// The same points always give the same faces in the same order
+func ConvexHull3(points []Po3) Hull3 {
+	if len(points) < 3 {
+		return Hull3{}
+	}
+
+	box := Box3FromPoints(points)
+	eps := 1e-5 * max(box.Size().Len(), 1)
+
+	// initial simplex: extremes along X, the point farthest from that line, then the farthest from that plane
+	i0, i1 := 0, 0
+	for i, p := range points {
+		if p.X < points[i0].X {
+			i0 = i
+		}
+		if p.X > points[i1].X {
+			i1 = i
+		}
+	}
+	if i0 == i1 {
+		// all points share X, fall back to the farthest pair from the first point
+		for i, p := range points {
+			if p.DistanceSquared(points[i0]) > points[i1].DistanceSquared(points[i0]) {
+				i1 = i
+			}
+		}
+		if i0 == i1 {
+			return Hull3{}
+		}
+	}
+
+	dir := points[i1].SubPo(points[i0]).Normalize()
+	i2, best := -1, eps
+	for i, p := range points {
+		if d := p.SubPo(points[i0]).Cross(dir).Len(); d > best {
+			i2, best = i, d
+		}
+	}
+	if i2 < 0 {
+		return Hull3{}
+	}
+
+	plane := PlaneFromPoints(points[i0], points[i1], points[i2])
+	i3, best := -1, eps
+	for i, p := range points {
+		if d := plane.Distance(p); d > best {
+			i3, best = i, d
+		}
+	}
+	if i3 < 0 {
+		return coplanarHull(points, plane.Normal)
+	}
+
+	inner := Vec3(points[i0]).Add(Vec3(points[i1])).Add(Vec3(points[i2])).Add(Vec3(points[i3])).Mul(0.25)
+
+	var faces []*hullFace
+	// byEdge finds the live face on the left of a directed edge, so faces can reach their neighbours
+	byEdge := make(map[[2]int]*hullFace)
+	link := func(f *hullFace) {
+		byEdge[[2]int{f.a, f.b}] = f
+		byEdge[[2]int{f.b, f.c}] = f
+		byEdge[[2]int{f.c, f.a}] = f
+	}
+	newFace := func(a, b, c int) *hullFace {
+		n := points[b].SubPo(points[a]).Cross(points[c].SubPo(points[a])).Normalize()
+		f := &hullFace{a: a, b: b, c: c, normal: n, d: n.Dot(Vec3(points[a]))}
+		if f.distance(Po3(inner)) > 0 {
+			f.b, f.c = f.c, f.b
+			f.normal = f.normal.Neg()
+			f.d = -f.d
+		}
+		faces = append(faces, f)
+		link(f)
+		return f
+	}
+
+	initial := []*hullFace{
+		newFace(i0, i1, i2),
+		newFace(i0, i1, i3),
+		newFace(i0, i2, i3),
+		newFace(i1, i2, i3),
+	}
+
+	assign := func(candidates []*hullFace, pts []int) {
+		for _, i := range pts {
+			for _, f := range candidates {
+				if f.distance(points[i]) > eps {
+					f.outside = append(f.outside, i)
+					break
+				}
+			}
+		}
+	}
+
+	all := make([]int, 0, len(points))
+	for i := range points {
+		if i != i0 && i != i1 && i != i2 && i != i3 {
+			all = append(all, i)
+		}
+	}
+	assign(initial, all)
+
+	for {
+		var face *hullFace
+		for _, f := range faces {
+			if !f.dead && len(f.outside) > 0 {
+				face = f
+				break
+			}
+		}
+		if face == nil {
+			break
+		}
+
+		// farthest point in front of the face
+		eye, far := -1, float32(0)
+		for _, i := range face.outside {
+			if d := face.distance(points[i]); d > far {
+				eye, far = i, d
+			}
+		}
+
+		// the faces the eye sees are found by walking out from this one, so they form one connected patch. The walk
+		// crosses faces the eye is barely in front of, otherwise a visible face behind them would be left standing.
+		// Edges from a visible face to a hidden one form the horizon
+		visible := []*hullFace{face}
+		face.dead = true
+		var horizon [][2]int
+		for k := 0; k < len(visible); k++ {
+			f := visible[k]
+			for _, e := range [3][2]int{{f.a, f.b}, {f.b, f.c}, {f.c, f.a}} {
+				n := byEdge[[2]int{e[1], e[0]}]
+				switch {
+				case n != nil && n.dead:
+				case n != nil && n.distance(points[eye]) > 0:
+					n.dead = true
+					visible = append(visible, n)
+				default:
+					horizon = append(horizon, e)
+				}
+			}
+		}
+
+		var orphans []int
+		for _, f := range visible {
+			delete(byEdge, [2]int{f.a, f.b})
+			delete(byEdge, [2]int{f.b, f.c})
+			delete(byEdge, [2]int{f.c, f.a})
+			orphans = append(orphans, f.outside...)
+			f.outside = nil
+		}
+
+		var created []*hullFace
+		for _, e := range hullHorizonLoop(horizon) {
+			n := points[e[1]].SubPo(points[e[0]]).Cross(points[eye].SubPo(points[e[0]])).Normalize()
+			f := &hullFace{a: e[0], b: e[1], c: eye, normal: n, d: n.Dot(Vec3(points[e[0]]))}
+			faces = append(faces, f)
+			link(f)
+			created = append(created, f)
+		}
+
+		orphans = slices.DeleteFunc(orphans, func(i int) bool { return i == eye })
+		assign(created, orphans)
+	}
+
+	return compactHull(points, faces)
+}
+
+// hullHorizonLoop orders the horizon edges so each starts where the previous one ended. Should near degenerate input
+// leave more than one loop, they follow each other in the order they were found
+func hullHorizonLoop(edges [][2]int) [][2]int {
+	from := make(map[int]int, len(edges))
+	for i, e := range edges {
+		from[e[0]] = i
+	}
+
+	out := make([][2]int, 0, len(edges))
+	used := make([]bool, len(edges))
+	for start := range edges {
+		for i := start; !used[i]; {
+			used[i] = true
+			out = append(out, edges[i])
+			next, ok := from[edges[i][1]]
+			if !ok {
+				break
+			}
+			i = next
+		}
+	}
+	return out
+}
+
+func compactHull(points []Po3, faces []*hullFace) Hull3 {
+	remap := make(map[int]int)
+	var hull Hull3
+
+	for _, f := range faces {
+		if f.dead {
+			continue
+		}
+		for _, i := range [3]int{f.a, f.b, f.c} {
+			j, ok := remap[i]
+			if !ok {
+				j = len(hull.Vertices)
+				remap[i] = j
+				hull.Vertices = append(hull.Vertices, points[i])
+			}
+			hull.Indices = append(hull.Indices, j)
+		}
+	}
+
+	return hull
+}
+
+// coplanarHull triangulates the 2D hull of points lying in one plane, once for each side
+func coplanarHull(points []Po3, normal Vec3) Hull3 {
+	u := normal.Cross(Vec3{1, 0, 0})
+	if u.LenSquared() < 1e-6 {
+		u = normal.Cross(Vec3{0, 1, 0})
+	}
+	u = u.Normalize()
+	v := normal.Cross(u)
+
+	flat := make([]Po2, len(points))
+	for i, p := range points {
+		flat[i] = Po2{u.Dot(Vec3(p)), v.Dot(Vec3(p))}
+	}
+
+	idx := convexHull2Indices(flat, false)
+	if len(idx) < 3 {
+		return Hull3{}
+	}
+
+	// u, v, normal is right handed so the 2D counter-clockwise order faces along normal
+	hull := Hull3{Vertices: make([]Po3, len(idx))}
+	for i, j := range idx {
+		hull.Vertices[i] = points[j]
+	}
+	for i := 1; i+1 < len(idx); i++ {
+		hull.Indices = append(hull.Indices, 0, i, i+1)
+	}
+	for i := 1; i+1 < len(idx); i++ {
+		hull.Indices = append(hull.Indices, 0, i+1, i)
+	}
+
+	return hull
+}
+
+// Volume returns the enclosed volume, it is negative if the faces point inwards
+func (h Hull3) Volume() float32 {
+	var v float32
+	for i := 0; i+2 < len(h.Indices); i += 3 {
+		a := Vec3(h.Vertices[h.Indices[i]])
+		b := Vec3(h.Vertices[h.Indices[i+1]])
+		c := Vec3(h.Vertices[h.Indices[i+2]])
+		v += a.Dot(b.Cross(c))
+	}
+	return v / 6
+}
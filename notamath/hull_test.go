@@ -0,0 +1,81 @@
+package notamath
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// hullCloud is a unit cube's corners, a grid on its faces and random points inside, lots of coplanar points make
+// the horizons of Quickhull hard to get right
+func hullCloud() []Po3 {
+	rng := rand.New(rand.NewSource(1))
+	var pts []Po3
+	for i := range 5 {
+		for j := range 5 {
+			a, b := float32(i)/4, float32(j)/4
+			pts = append(pts, Po3{a, b, 0}, Po3{a, b, 1}, Po3{a, 0, b}, Po3{a, 1, b}, Po3{0, a, b}, Po3{1, a, b})
+		}
+	}
+	for range 200 {
+		pts = append(pts, Po3{rng.Float32(), rng.Float32(), rng.Float32()})
+	}
+	rng.Shuffle(len(pts), func(i, j int) { pts[i], pts[j] = pts[j], pts[i] })
+	return pts
+}
+
+// checkClosedHull verifies that every edge is shared by exactly two faces wound against each other and that no
+// point lies outside
+func checkClosedHull(t *testing.T, h Hull3, points []Po3) {
+	t.Helper()
+	edges := map[[2]int]int{}
+	for i := 0; i+2 < len(h.Indices); i += 3 {
+		a, b, c := h.Indices[i], h.Indices[i+1], h.Indices[i+2]
+		edges[[2]int{a, b}]++
+		edges[[2]int{b, c}]++
+		edges[[2]int{c, a}]++
+	}
+	for e, n := range edges {
+		if n != 1 || edges[[2]int{e[1], e[0]}] != 1 {
+			t.Fatalf("edge %v is used %d times and its twin %d times", e, n, edges[[2]int{e[1], e[0]}])
+		}
+	}
+
+	for f := 0; f < len(h.Indices)/3; f++ {
+		n := h.Normal(f)
+		d := n.Dot(Vec3(h.Vertices[h.Indices[f*3]]))
+		for _, p := range points {
+			if dist := n.Dot(Vec3(p)) - d; dist > 1e-4 {
+				t.Fatalf("point %v is %f outside face %d", p, dist, f)
+			}
+		}
+	}
+}
+
+func TestConvexHull3Closed(t *testing.T) {
+	pts := hullCloud()
+	h := ConvexHull3(pts)
+	checkClosedHull(t, h, pts)
+	if v := h.Volume(); math.Abs(float64(v)-1) > 1e-4 {
+		t.Errorf("volume %f, want 1", v)
+	}
+
+	rng := NewRand(2)
+	sphere := make([]Po3, 500)
+	for i := range sphere {
+		sphere[i] = Po3(rng.OnUnitSphere())
+	}
+	checkClosedHull(t, ConvexHull3(sphere), sphere)
+}
+
+func TestConvexHull3Deterministic(t *testing.T) {
+	pts := hullCloud()
+	want := ConvexHull3(pts)
+	for range 20 {
+		got := ConvexHull3(pts)
+		if !slices.Equal(got.Vertices, want.Vertices) || !slices.Equal(got.Indices, want.Indices) {
+			t.Fatal("the same points gave a different hull")
+		}
+	}
+}
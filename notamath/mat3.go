@@ -52,13 +52,14 @@ func Mat3Shear(kx, ky float32) Mat3 {
 	}}
 }
 
+// Mat3TRS scales, then rotates, then translates (T * R * S) like Mat4TRS, so the scale follows the local axes
 func Mat3TRS(pos Vec2, rot float32, scale Vec2) Mat3 {
 	c := float32(math.Cos(float64(rot)))
 	s := float32(math.Sin(float64(rot)))
 
 	return Mat3{M: [9]float32{
-		scale.X * c, -scale.X * s, pos.X,
-		scale.Y * s, scale.Y * c, pos.Y,
+		c * scale.X, -s * scale.Y, pos.X,
+		s * scale.X, c * scale.Y, pos.Y,
 		0, 0, 1,
 	}}
 }
@@ -93,6 +94,35 @@ func (m Mat3) TransformVec2(v Vec2) Vec2 {
 	}
 }
 
+// TransformPo2s writes src transformed into dst and returns it, dst only grows when it is too small so a reused
+// buffer makes this allocation free. dst and src may be the same slice
+func (m Mat3) TransformPo2s(dst, src []Po2) []Po2 {
+	dst = resize(dst, len(src))
+	a, b, c, d, e, f := m.M[0], m.M[1], m.M[2], m.M[3], m.M[4], m.M[5]
+	for i, p := range src {
+		dst[i] = Po2{a*p.X + b*p.Y + c, d*p.X + e*p.Y + f}
+	}
+	return dst
+}
+
+// TransformVec2s is TransformPo2s for vectors, translation is ignored
+func (m Mat3) TransformVec2s(dst, src []Vec2) []Vec2 {
+	dst = resize(dst, len(src))
+	a, b, d, e := m.M[0], m.M[1], m.M[3], m.M[4]
+	for i, v := range src {
+		dst[i] = Vec2{a*v.X + b*v.Y, d*v.X + e*v.Y}
+	}
+	return dst
+}
+
+// resize returns s with length n, reusing its backing array when it is large enough
+func resize[T any](s []T, n int) []T {
+	if cap(s) < n {
+		return make([]T, n)
+	}
+	return s[:n]
+}
+
 func (m Mat3) Transpose() Mat3 {
 	return Mat3{M: [9]float32{
 		m.M[0], m.M[3], m.M[6],
@@ -102,14 +132,41 @@ func (m Mat3) Transpose() Mat3 {
 }
 
 func (m Mat3) Det() float32 {
-	return m.M[0]*m.M[4] - m.M[1]*m.M[3]
+	return m.M[0]*(m.M[4]*m.M[8]-m.M[5]*m.M[7]) -
+		m.M[1]*(m.M[3]*m.M[8]-m.M[5]*m.M[6]) +
+		m.M[2]*(m.M[3]*m.M[7]-m.M[4]*m.M[6])
+}
+
+// Inverse returns the full inverse of the matrix, or the identity if the matrix is singular
+func (m Mat3) Inverse() Mat3 {
+	det := m.Det()
+	if det == 0 {
+		return Mat3Identity()
+	}
+
+	invDet := 1 / det
+	a := m.M
+
+	return Mat3{M: [9]float32{
+		(a[4]*a[8] - a[5]*a[7]) * invDet,
+		(a[2]*a[7] - a[1]*a[8]) * invDet,
+		(a[1]*a[5] - a[2]*a[4]) * invDet,
+
+		(a[5]*a[6] - a[3]*a[8]) * invDet,
+		(a[0]*a[8] - a[2]*a[6]) * invDet,
+		(a[2]*a[3] - a[0]*a[5]) * invDet,
+
+		(a[3]*a[7] - a[4]*a[6]) * invDet,
+		(a[1]*a[6] - a[0]*a[7]) * invDet,
+		(a[0]*a[4] - a[1]*a[3]) * invDet,
+	}}
 }
 
 func (m Mat3) InverseAffine() Mat3 {
 	a, b, c := m.M[0], m.M[1], m.M[2]
 	d, e, f := m.M[3], m.M[4], m.M[5]
 
-	det := m.Det()
+	det := a*e - b*d
 	if det == 0 {
 		return Mat3Identity()
 	}
@@ -123,6 +180,28 @@ func (m Mat3) InverseAffine() Mat3 {
 	}}
 }
 
+// Decompose splits an affine matrix built like Mat3TRS back into position, rotation in radians and scale.
+// Shear is not recovered, a mirrored matrix gets a negative X scale
+func (m Mat3) Decompose() (pos Vec2, rot float32, scale Vec2) {
+	pos = Vec2{m.M[2], m.M[5]}
+
+	// Mat3TRS scales the columns of the rotation: column 0 is sx*(c, s), column 1 is sy*(-s, c)
+	scale.X = float32(math.Hypot(float64(m.M[0]), float64(m.M[3])))
+	scale.Y = float32(math.Hypot(float64(m.M[1]), float64(m.M[4])))
+
+	if m.M[0]*m.M[4]-m.M[1]*m.M[3] < 0 {
+		scale.X = -scale.X
+	}
+
+	if scale.X != 0 {
+		rot = float32(math.Atan2(float64(m.M[3]/scale.X), float64(m.M[0]/scale.X)))
+	} else if scale.Y != 0 {
+		rot = float32(math.Atan2(float64(-m.M[1]/scale.Y), float64(m.M[4]/scale.Y)))
+	}
+
+	return pos, rot, scale
+}
+
 func (m Mat3) String() string {
 	return fmt.Sprintf(
 		"[%f %f %f\n %f %f %f\n %f %f %f]",
@@ -0,0 +1,107 @@
+package notamath
+
+import (
+	"math"
+	"testing"
+)
+
+const matEps = 1e-4
+
+func mat3Near(a, b Mat3) bool {
+	for i := range a.M {
+		if math.Abs(float64(a.M[i]-b.M[i])) > matEps {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMat3InverseTimesMatrixIsIdentity(t *testing.T) {
+	cases := map[string]Mat3{
+		"identity":    Mat3Identity(),
+		"trs":         Mat3TRS(Vec2{3, -2}, 0.7, Vec2{2, 0.5}),
+		"mirrored":    Mat3TRS(Vec2{-1, 4}, -1.2, Vec2{-3, 1.5}),
+		"shear":       Mat3Translation(Vec2{1, 2}).Mul(Mat3Shear(0.3, -0.4)),
+		"projective":  {M: [9]float32{2, 1, 0, 0, 3, 1, 1, 0, 4}},
+		"translation": Mat3Translation(Vec2{10, -5}),
+	}
+	for name, m := range cases {
+		if got := m.Inverse().Mul(m); !mat3Near(got, Mat3Identity()) {
+			t.Errorf("%s: Inverse * M =\n%v", name, got)
+		}
+		if got := m.Mul(m.Inverse()); !mat3Near(got, Mat3Identity()) {
+			t.Errorf("%s: M * Inverse =\n%v", name, got)
+		}
+	}
+}
+
+func TestMat3InverseAffineMatchesInverse(t *testing.T) {
+	m := Mat3TRS(Vec2{3, -2}, 0.7, Vec2{2, 0.5}).Mul(Mat3Shear(0.2, 0))
+	if !mat3Near(m.InverseAffine(), m.Inverse()) {
+		t.Errorf("InverseAffine =\n%v\nInverse =\n%v", m.InverseAffine(), m.Inverse())
+	}
+}
+
+func TestMat3DecomposeRoundTrip(t *testing.T) {
+	cases := []struct {
+		name  string
+		pos   Vec2
+		rot   float32
+		scale Vec2
+	}{
+		{"identity", Vec2{}, 0, Vec2{1, 1}},
+		{"uniform", Vec2{3, -2}, 0.7, Vec2{2, 2}},
+		{"non uniform", Vec2{-5, 1}, 2.5, Vec2{3, 0.25}},
+		{"mirrored x", Vec2{1, 1}, -0.4, Vec2{-2, 1}},
+		{"mirrored y", Vec2{1, 1}, 1.1, Vec2{2, -3}},
+		{"zero x", Vec2{4, 0}, 0.9, Vec2{0, 2}},
+		{"zero y", Vec2{0, 4}, -2, Vec2{1.5, 0}},
+		{"zero", Vec2{2, 2}, 1, Vec2{}},
+	}
+	for _, c := range cases {
+		m := Mat3TRS(c.pos, c.rot, c.scale)
+		pos, rot, scale := m.Decompose()
+		if got := Mat3TRS(pos, rot, scale); !mat3Near(got, m) {
+			t.Errorf("%s: TRS(Decompose) =\n%v\nwant\n%v", c.name, got, m)
+		}
+		if pos != c.pos {
+			t.Errorf("%s: pos = %v, want %v", c.name, pos, c.pos)
+		}
+	}
+}
+
+func TestMat3TRSMatchesProduct(t *testing.T) {
+	pos, rot, scale := Vec2{3, -2}, float32(0.7), Vec2{2, 0.5}
+	want := Mat3Translation(pos).Mul(Mat3Rotation(rot)).Mul(Mat3Scale(scale))
+	if got := Mat3TRS(pos, rot, scale); !mat3Near(got, want) {
+		t.Errorf("Mat3TRS =\n%v\nwant T * R * S =\n%v", got, want)
+	}
+}
+
+// BenchmarkMat3TransformPo2s transforms a batch into a reused buffer, against transforming point by point into a
+// new slice
+func BenchmarkMat3TransformPo2s(b *testing.B) {
+	m := Mat3TRS(Vec2{3, -2}, 0.7, Vec2{2, 0.5})
+	src := make([]Po2, 1024)
+	for i := range src {
+		src[i] = Po2{X: float32(i), Y: float32(-i)}
+	}
+
+	b.Run("batch", func(b *testing.B) {
+		var dst []Po2
+		b.ReportAllocs()
+		for b.Loop() {
+			dst = m.TransformPo2s(dst, src)
+		}
+	})
+
+	b.Run("per point", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var dst []Po2
+			for _, p := range src {
+				dst = append(dst, m.TransformPo2(p))
+			}
+		}
+	})
+}
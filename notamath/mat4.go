@@ -130,9 +130,9 @@ func (m Mat4) SmartMul(b Mat4) Mat4 {
 		sx, sy, sz := b.M[0], b.M[5], b.M[10]
 
 		return Mat4{M: [16]float32{
-			m.M[0] * sx, m.M[1] * sx, m.M[2] * sx, m.M[3],
-			m.M[4] * sy, m.M[5] * sy, m.M[6] * sy, m.M[7],
-			m.M[8] * sz, m.M[9] * sz, m.M[10] * sz, m.M[11],
+			m.M[0] * sx, m.M[1] * sy, m.M[2] * sz, m.M[3],
+			m.M[4] * sx, m.M[5] * sy, m.M[6] * sz, m.M[7],
+			m.M[8] * sx, m.M[9] * sy, m.M[10] * sz, m.M[11],
 			m.M[12], m.M[13], m.M[14], m.M[15],
 		}}
 	}
@@ -207,6 +207,32 @@ func (m Mat4) TransformVec3(v Vec3) Vec3 {
 	}
 }
 
+// TransformPo3s writes src transformed into dst and returns it, see Mat3.TransformPo2s
+func (m Mat4) TransformPo3s(dst, src []Po3) []Po3 {
+	dst = resize(dst, len(src))
+	for i, p := range src {
+		dst[i] = Po3{
+			X: m.M[0]*p.X + m.M[1]*p.Y + m.M[2]*p.Z + m.M[3],
+			Y: m.M[4]*p.X + m.M[5]*p.Y + m.M[6]*p.Z + m.M[7],
+			Z: m.M[8]*p.X + m.M[9]*p.Y + m.M[10]*p.Z + m.M[11],
+		}
+	}
+	return dst
+}
+
+// TransformVec3s is TransformPo3s for vectors, translation is ignored
+func (m Mat4) TransformVec3s(dst, src []Vec3) []Vec3 {
+	dst = resize(dst, len(src))
+	for i, v := range src {
+		dst[i] = Vec3{
+			X: m.M[0]*v.X + m.M[1]*v.Y + m.M[2]*v.Z,
+			Y: m.M[4]*v.X + m.M[5]*v.Y + m.M[6]*v.Z,
+			Z: m.M[8]*v.X + m.M[9]*v.Y + m.M[10]*v.Z,
+		}
+	}
+	return dst
+}
+
 func Mat4TRS(pos Vec3, axis Vec3, angle float32, scale Vec3) Mat4 {
 	t := Mat4Translation(pos)
 	r := Mat4RotationAxisAngle(axis, angle)
@@ -214,6 +240,13 @@ func Mat4TRS(pos Vec3, axis Vec3, angle float32, scale Vec3) Mat4 {
 	return t.SmartMul(r).SmartMul(s)
 }
 
+func Mat4TRSQuat(pos Vec3, rot Quat, scale Vec3) Mat4 {
+	t := Mat4Translation(pos)
+	r := rot.Mat4()
+	s := Mat4Scale(scale)
+	return t.SmartMul(r).SmartMul(s)
+}
+
 func floatEqual(a, b, eps float32) bool {
 	diff := a - b
 	if diff < 0 {
@@ -303,6 +336,151 @@ func (m Mat4) InverseAffine() Mat4 {
 	}}
 }
 
+func (m Mat4) Det() float32 {
+	a := m.M
+
+	s0 := a[0]*a[5] - a[4]*a[1]
+	s1 := a[0]*a[6] - a[4]*a[2]
+	s2 := a[0]*a[7] - a[4]*a[3]
+	s3 := a[1]*a[6] - a[5]*a[2]
+	s4 := a[1]*a[7] - a[5]*a[3]
+	s5 := a[2]*a[7] - a[6]*a[3]
+
+	c5 := a[10]*a[15] - a[14]*a[11]
+	c4 := a[9]*a[15] - a[13]*a[11]
+	c3 := a[9]*a[14] - a[13]*a[10]
+	c2 := a[8]*a[15] - a[12]*a[11]
+	c1 := a[8]*a[14] - a[12]*a[10]
+	c0 := a[8]*a[13] - a[12]*a[9]
+
+	return s0*c5 - s1*c4 + s2*c3 + s3*c2 - s4*c1 + s5*c0
+}
+
+// Inverse returns the full inverse of the matrix (works for projections too), or the identity if the matrix is singular
+func (m Mat4) Inverse() Mat4 {
+	a := m.M
+
+	// 2x2 sub-determinants of the top two and bottom two rows (Laplace expansion)
+	s0 := a[0]*a[5] - a[4]*a[1]
+	s1 := a[0]*a[6] - a[4]*a[2]
+	s2 := a[0]*a[7] - a[4]*a[3]
+	s3 := a[1]*a[6] - a[5]*a[2]
+	s4 := a[1]*a[7] - a[5]*a[3]
+	s5 := a[2]*a[7] - a[6]*a[3]
+
+	c5 := a[10]*a[15] - a[14]*a[11]
+	c4 := a[9]*a[15] - a[13]*a[11]
+	c3 := a[9]*a[14] - a[13]*a[10]
+	c2 := a[8]*a[15] - a[12]*a[11]
+	c1 := a[8]*a[14] - a[12]*a[10]
+	c0 := a[8]*a[13] - a[12]*a[9]
+
+	det := s0*c5 - s1*c4 + s2*c3 + s3*c2 - s4*c1 + s5*c0
+	if det == 0 {
+		return Mat4Identity()
+	}
+
+	invDet := 1 / det
+
+	return Mat4{M: [16]float32{
+		(a[5]*c5 - a[6]*c4 + a[7]*c3) * invDet,
+		(-a[1]*c5 + a[2]*c4 - a[3]*c3) * invDet,
+		(a[13]*s5 - a[14]*s4 + a[15]*s3) * invDet,
+		(-a[9]*s5 + a[10]*s4 - a[11]*s3) * invDet,
+
+		(-a[4]*c5 + a[6]*c2 - a[7]*c1) * invDet,
+		(a[0]*c5 - a[2]*c2 + a[3]*c1) * invDet,
+		(-a[12]*s5 + a[14]*s2 - a[15]*s1) * invDet,
+		(a[8]*s5 - a[10]*s2 + a[11]*s1) * invDet,
+
+		(a[4]*c4 - a[5]*c2 + a[7]*c0) * invDet,
+		(-a[0]*c4 + a[1]*c2 - a[3]*c0) * invDet,
+		(a[12]*s4 - a[13]*s2 + a[15]*s0) * invDet,
+		(-a[8]*s4 + a[9]*s2 - a[11]*s0) * invDet,
+
+		(-a[4]*c3 + a[5]*c1 - a[6]*c0) * invDet,
+		(a[0]*c3 - a[1]*c1 + a[2]*c0) * invDet,
+		(-a[12]*s3 + a[13]*s1 - a[14]*s0) * invDet,
+		(a[8]*s3 - a[9]*s1 + a[10]*s0) * invDet,
+	}}
+}
+
+// TransformPo3Projective applies the matrix and divides by w, used to unproject through an inverse view-projection
+func (m Mat4) TransformPo3Projective(p Po3) Po3 {
+	w := m.M[12]*p.X + m.M[13]*p.Y + m.M[14]*p.Z + m.M[15]
+	if w == 0 {
+		w = 1
+	}
+	invW := 1 / w
+
+	return Po3{
+		X: (m.M[0]*p.X + m.M[1]*p.Y + m.M[2]*p.Z + m.M[3]) * invW,
+		Y: (m.M[4]*p.X + m.M[5]*p.Y + m.M[6]*p.Z + m.M[7]) * invW,
+		Z: (m.M[8]*p.X + m.M[9]*p.Y + m.M[10]*p.Z + m.M[11]) * invW,
+	}
+}
+
+// Decompose splits an affine matrix built like Mat4TRS back into position, rotation and scale.
+// Shear is not recovered, a mirrored matrix gets a negative X scale
+func (m Mat4) Decompose() (pos Vec3, rot Quat, scale Vec3) {
+	pos = Vec3{m.M[3], m.M[7], m.M[11]}
+
+	// Mat4TRS scales the columns of the rotation
+	cx := Vec3{m.M[0], m.M[4], m.M[8]}
+	cy := Vec3{m.M[1], m.M[5], m.M[9]}
+	cz := Vec3{m.M[2], m.M[6], m.M[10]}
+
+	scale = Vec3{cx.Len(), cy.Len(), cz.Len()}
+	if cx.Cross(cy).Dot(cz) < 0 {
+		scale.X = -scale.X
+	}
+
+	// a zero scale flattens its column, any unit axis completing the rotation rebuilds the same matrix
+	switch {
+	case scale.X != 0 && scale.Y != 0 && scale.Z != 0:
+		cx, cy, cz = cx.Div(scale.X), cy.Div(scale.Y), cz.Div(scale.Z)
+	case scale.Y != 0 && scale.Z != 0:
+		cy, cz = cy.Div(scale.Y), cz.Div(scale.Z)
+		cx = cy.Cross(cz)
+	case scale.X != 0 && scale.Z != 0:
+		cx, cz = cx.Div(scale.X), cz.Div(scale.Z)
+		cy = cz.Cross(cx)
+	case scale.X != 0 && scale.Y != 0:
+		cx, cy = cx.Div(scale.X), cy.Div(scale.Y)
+		cz = cx.Cross(cy)
+	case scale.X != 0:
+		cx = cx.Div(scale.X)
+		cy = perpendicular(cx)
+		cz = cx.Cross(cy)
+	case scale.Y != 0:
+		cy = cy.Div(scale.Y)
+		cz = perpendicular(cy)
+		cx = cy.Cross(cz)
+	case scale.Z != 0:
+		cz = cz.Div(scale.Z)
+		cx = perpendicular(cz)
+		cy = cz.Cross(cx)
+	default:
+		return pos, QuatIdentity(), scale
+	}
+
+	rot = quatFromBasis(
+		cx.X, cy.X, cz.X,
+		cx.Y, cy.Y, cz.Y,
+		cx.Z, cy.Z, cz.Z,
+	)
+	return pos, rot, scale
+}
+
+// perpendicular returns a unit vector at a right angle to the unit vector v
+func perpendicular(v Vec3) Vec3 {
+	axis := Vec3{1, 0, 0}
+	if float32(math.Abs(float64(v.X))) > 0.9 {
+		axis = Vec3{0, 1, 0}
+	}
+	return v.Cross(axis).Normalize()
+}
+
 // NormalMatrix returns the inverse transpose of the upper-left 3x3 part of the matrix, ignoring translation
 func (m Mat4) NormalMatrix() Mat3 {
 	a00, a01, a02 := m.M[0], m.M[1], m.M[2]
@@ -0,0 +1,74 @@
+package notamath
+
+import (
+	"math"
+	"testing"
+)
+
+func mat4Near(a, b Mat4) bool {
+	for i := range a.M {
+		if math.Abs(float64(a.M[i]-b.M[i])) > matEps {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMat4InverseTimesMatrixIsIdentity(t *testing.T) {
+	cases := map[string]Mat4{
+		"identity":    Mat4Identity(),
+		"trs":         Mat4TRS(Vec3{1, 2, 3}, Vec3{1, 1, 0}.Normalize(), 0.8, Vec3{2, 0.5, 3}),
+		"mirrored":    Mat4TRS(Vec3{-4, 0, 2}, Vec3{0, 0, 1}, -1.3, Vec3{-1, 2, 1}),
+		"perspective": Mat4Perspective(1.2, 16.0/9, 0.1, 100),
+		"view":        Mat4LookAt(Vec3{3, 4, 5}, Vec3{}, Vec3{0, 1, 0}),
+		"ortho":       Mat4Ortho(-4, 4, -3, 3, 0.1, 50),
+	}
+	for name, m := range cases {
+		if got := m.Inverse().Mul(m); !mat4Near(got, Mat4Identity()) {
+			t.Errorf("%s: Inverse * M = %v", name, got.M)
+		}
+		if got := m.Mul(m.Inverse()); !mat4Near(got, Mat4Identity()) {
+			t.Errorf("%s: M * Inverse = %v", name, got.M)
+		}
+	}
+}
+
+func TestMat4InverseAffineMatchesInverse(t *testing.T) {
+	m := Mat4TRS(Vec3{1, 2, 3}, Vec3{0, 1, 0}, 0.8, Vec3{2, 0.5, 3})
+	if !mat4Near(m.InverseAffine(), m.Inverse()) {
+		t.Errorf("InverseAffine = %v, Inverse = %v", m.InverseAffine().M, m.Inverse().M)
+	}
+}
+
+func TestMat4DecomposeRoundTrip(t *testing.T) {
+	axis := Vec3{1, 2, -1}.Normalize()
+	cases := []struct {
+		name  string
+		pos   Vec3
+		angle float32
+		scale Vec3
+	}{
+		{"identity", Vec3{}, 0, Vec3{1, 1, 1}},
+		{"uniform", Vec3{1, 2, 3}, 0.8, Vec3{2, 2, 2}},
+		{"non uniform", Vec3{-3, 0, 7}, 2.4, Vec3{3, 0.25, 1.5}},
+		{"mirrored x", Vec3{1, 1, 1}, -0.6, Vec3{-2, 1, 1}},
+		{"mirrored z", Vec3{1, 1, 1}, 1.3, Vec3{1, 2, -3}},
+		{"zero x", Vec3{0, 5, 0}, 0.9, Vec3{0, 2, 1}},
+		{"zero y", Vec3{0, 5, 0}, 0.9, Vec3{2, 0, 1}},
+		{"zero z", Vec3{0, 5, 0}, 0.9, Vec3{2, 1, 0}},
+		{"only x", Vec3{1, 0, 0}, -2.2, Vec3{3, 0, 0}},
+		{"only y", Vec3{1, 0, 0}, -2.2, Vec3{0, 3, 0}},
+		{"only z", Vec3{1, 0, 0}, -2.2, Vec3{0, 0, 3}},
+		{"zero", Vec3{2, 2, 2}, 1, Vec3{}},
+	}
+	for _, c := range cases {
+		m := Mat4TRS(c.pos, axis, c.angle, c.scale)
+		pos, rot, scale := m.Decompose()
+		if got := Mat4TRSQuat(pos, rot, scale); !mat4Near(got, m) {
+			t.Errorf("%s: TRS(Decompose) = %v, want %v", c.name, got.M, m.M)
+		}
+		if n := rot.Len(); math.Abs(float64(n-1)) > matEps {
+			t.Errorf("%s: rotation is not a unit quaternion, len %f", c.name, n)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package notamath
+
+import "math"
+
+// JoinType selects how PolygonOffset fills the corners where offset edges meet
+type JoinType int
+
+const (
+	JoinMiter JoinType = iota
+	JoinRound
+	JoinBevel
+)
+
+// roundJoinSegments is the number of segments used for a full circle of a round join
+const roundJoinSegments = 32
+
+// PolygonOffset grows the shape by delta, or shrinks it when delta is negative. The rings follow the same even-odd
+// rule as PolygonBoolean and it fails the same way. Miter joins longer than miterLimit times delta fall back to
+// bevels, 0 means 2
+func PolygonOffset(rings [][]Po2, delta float32, join JoinType, miterLimit float32) ([][]Po2, error) {
+	if delta == 0 {
+		return PolygonBoolean(OpUnion, rings, nil)
+	}
+	if miterLimit <= 0 {
+		miterLimit = 2
+	}
+
+	d := absf(delta)
+	buffer := offsetBuffer(rings, d, join, miterLimit)
+
+	if delta > 0 {
+		return clipRings(OpUnion, rings, fillEvenOdd, buffer, fillNonZero)
+	}
+	return clipRings(OpDifference, rings, fillEvenOdd, buffer, fillNonZero)
+}
+
+// offsetBuffer covers every point within d of the outline: a rectangle per edge plus a join piece per corner
+func offsetBuffer(rings [][]Po2, d float32, join JoinType, miterLimit float32) [][]Po2 {
+	var buffer [][]Po2
+
+	for _, ring := range rings {
+		n := len(ring)
+		for i := range ring {
+			p := ring[i]
+			q := ring[(i+1)%n]
+			dir := q.Sub(p).Normalize()
+			if dir == (Vec2{}) {
+				continue
+			}
+
+			off := Vec2{-dir.Y, dir.X}.Mul(d)
+			buffer = append(buffer, counterClockwise([]Po2{
+				p.Add(off.Neg()), q.Add(off.Neg()), q.Add(off), p.Add(off),
+			}))
+		}
+
+		for i := range ring {
+			prev := ring[(i+n-1)%n]
+			v := ring[i]
+			next := ring[(i+1)%n]
+
+			if piece := offsetJoin(prev, v, next, d, join, miterLimit); piece != nil {
+				buffer = append(buffer, piece)
+			}
+		}
+	}
+
+	return buffer
+}
+
+// offsetJoin fills the wedge left open between the rectangles of two edges on the outer side of the turn
+func offsetJoin(prev, v, next Po2, d float32, join JoinType, miterLimit float32) []Po2 {
+	d1 := v.Sub(prev).Normalize()
+	d2 := next.Sub(v).Normalize()
+	if d1 == (Vec2{}) || d2 == (Vec2{}) {
+		return nil
+	}
+
+	if join == JoinRound {
+		circle := make([]Po2, roundJoinSegments)
+		for k := range circle {
+			a := float64(k) * 2 * math.Pi / roundJoinSegments
+			circle[k] = Po2{v.X + d*float32(math.Cos(a)), v.Y + d*float32(math.Sin(a))}
+		}
+		return circle
+	}
+
+	turn := d1.Cross(d2)
+	if absf(turn) < 1e-6 && d1.Dot(d2) > 0 {
+		return nil
+	}
+
+	// the gap is on the right of a left turn and on the left of a right turn
+	n1 := Vec2{d1.Y, -d1.X}
+	n2 := Vec2{d2.Y, -d2.X}
+	if turn < 0 {
+		n1, n2 = n1.Neg(), n2.Neg()
+	}
+
+	a := v.Add(n1.Mul(d))
+	b := v.Add(n2.Mul(d))
+
+	if join == JoinMiter {
+		if cos := 1 + n1.Dot(n2); cos > 1e-6 {
+			miter := n1.Add(n2).Mul(d / cos)
+			if miter.Len() <= miterLimit*d {
+				return counterClockwise([]Po2{v, a, v.Add(miter), b})
+			}
+		}
+	}
+
+	return counterClockwise([]Po2{v, a, b})
+}
+
+func counterClockwise(ring []Po2) []Po2 {
+	if SignedArea(ring) < 0 {
+		for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+			ring[i], ring[j] = ring[j], ring[i]
+		}
+	}
+	return ring
+}
+
+// PolygonShape is an outline with the holes cut out of it
+type PolygonShape struct {
+	Outer []Po2
+	Holes [][]Po2
+}
+
+// GroupRings pairs the counter-clockwise outlines returned by the polygon operations with the clockwise holes
+// inside them
+func GroupRings(rings [][]Po2) []PolygonShape {
+	var shapes []PolygonShape
+	var holes [][]Po2
+
+	for _, r := range rings {
+		if SignedArea(r) >= 0 {
+			shapes = append(shapes, PolygonShape{Outer: r})
+		} else {
+			holes = append(holes, r)
+		}
+	}
+
+	for _, h := range holes {
+		// the smallest outline containing the hole owns it
+		best := -1
+		var bestArea float32
+		probe := ringInteriorPoint(h)
+		for i, s := range shapes {
+			if !pointInRing(probe, s.Outer) {
+				continue
+			}
+			if a := SignedArea(s.Outer); best < 0 || a < bestArea {
+				best, bestArea = i, a
+			}
+		}
+		if best >= 0 {
+			shapes[best].Holes = append(shapes[best].Holes, h)
+		}
+	}
+
+	return shapes
+}
+
+// ringInteriorPoint returns a point just inside a clockwise ring, next to the middle of its longest edge
+func ringInteriorPoint(ring []Po2) Po2 {
+	best := 0
+	for i := range ring {
+		if ring[i].DistanceSquared(ring[(i+1)%len(ring)]) > ring[best].DistanceSquared(ring[(best+1)%len(ring)]) {
+			best = i
+		}
+	}
+	a := ring[best]
+	b := ring[(best+1)%len(ring)]
+	dir := b.Sub(a)
+	mid := a.Add(dir.Mul(0.5))
+	// clockwise rings have their inside on the right
+	return mid.Add(Vec2{dir.Y, -dir.X}.Mul(1e-3))
+}
+
+func pointInRing(p Po2, ring []Po2) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Bridged joins the holes to the outline with zero width cuts, giving one ring that Triangulate and the
+// renderer's ear clipping accept
+func (s PolygonShape) Bridged() []Po2 {
+	outer := counterClockwise(append([]Po2(nil), s.Outer...))
+
+	holes := make([][]Po2, 0, len(s.Holes))
+	for _, h := range s.Holes {
+		if len(h) < 3 {
+			continue
+		}
+		h = append([]Po2(nil), h...)
+		if SignedArea(h) > 0 {
+			for i, j := 0, len(h)-1; i < j; i, j = i+1, j-1 {
+				h[i], h[j] = h[j], h[i]
+			}
+		}
+		holes = append(holes, h)
+	}
+
+	// bridging the rightmost holes first keeps later bridges from crossing earlier ones
+	rightmost := func(h []Po2) int {
+		r := 0
+		for i, p := range h {
+			if p.X > h[r].X || (p.X == h[r].X && p.Y < h[r].Y) {
+				r = i
+			}
+		}
+		return r
+	}
+	for i := 1; i < len(holes); i++ {
+		for j := i; j > 0 && holes[j][rightmost(holes[j])].X > holes[j-1][rightmost(holes[j-1])].X; j-- {
+			holes[j], holes[j-1] = holes[j-1], holes[j]
+		}
+	}
+
+	for _, h := range holes {
+		m := rightmost(h)
+		o := bridgeVertex(outer, h[m])
+		if o < 0 {
+			continue
+		}
+
+		merged := make([]Po2, 0, len(outer)+len(h)+2)
+		merged = append(merged, outer[:o+1]...)
+		for k := 0; k <= len(h); k++ {
+			merged = append(merged, h[(m+k)%len(h)])
+		}
+		merged = append(merged, outer[o:]...)
+		outer = merged
+	}
+
+	return outer
+}
+
+// bridgeVertex finds an outline vertex visible from the hole point m by casting a ray towards +X
+func bridgeVertex(outer []Po2, m Po2) int {
+	n := len(outer)
+	hitX := float32(math.Inf(1))
+	edge := -1
+
+	for i := range outer {
+		a := outer[i]
+		b := outer[(i+1)%n]
+		if a.Y == b.Y || m.Y < min(a.Y, b.Y) || m.Y > max(a.Y, b.Y) {
+			continue
+		}
+		x := a.X + (m.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+		if x >= m.X && x < hitX {
+			hitX, edge = x, i
+		}
+	}
+	if edge < 0 {
+		return -1
+	}
+
+	// start with the endpoint of the hit edge furthest along X, then prefer reflex vertices hiding inside the
+	// triangle between m, the hit point and that endpoint
+	hit := Po2{hitX, m.Y}
+	cand := edge
+	if outer[(edge+1)%n].X > outer[edge].X {
+		cand = (edge + 1) % n
+	}
+	if outer[cand] == hit {
+		return cand
+	}
+
+	best := cand
+	bestAngle := angleFromX(outer[cand].Sub(m))
+	for i := range outer {
+		p := outer[i]
+		if i == cand || p.X < m.X {
+			continue
+		}
+		prev := outer[(i+n-1)%n]
+		next := outer[(i+1)%n]
+		if Orient(prev, p, next) > 0 {
+			continue
+		}
+		if !PointInTriangle(p, m, hit, outer[cand]) && !PointInTriangle(p, m, outer[cand], hit) {
+			continue
+		}
+		if a := angleFromX(p.Sub(m)); a < bestAngle || (a == bestAngle && p.DistanceSquared(m) < outer[best].DistanceSquared(m)) {
+			best, bestAngle = i, a
+		}
+	}
+
+	return best
+}
+
+func angleFromX(v Vec2) float32 {
+	return absf(float32(math.Atan2(float64(v.Y), float64(v.X))))
+}
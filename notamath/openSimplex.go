@@ -0,0 +1,171 @@
+package notamath
+
+import "math"
+
+// OpenSimplex is seedable OpenSimplex2 noise. 2D sums the corners of the triangular simplex lattice, 3D a rotated
+// body centred cubic lattice and 4D the A4* lattice, every lattice point in reach adds a (r² - d²)⁴ falloff times its
+// gradient. Unlike Simplex it has no seams and no grid aligned look in 3D and 4D. Every NoiseN returns values roughly
+// in [-1, 1]
+type OpenSimplex struct {
+	perm [512]uint8
+}
+
+func NewOpenSimplex(seed uint64) *OpenSimplex {
+	o := &OpenSimplex{}
+	o.perm = permutation(seed)
+	return o
+}
+
+// the reciprocals of the largest value each dimension reaches, so the output spans [-1, 1]
+const (
+	openSimplexScale2 = 1 / 0.01001634
+	openSimplexScale3 = 1 / 0.07969838
+	openSimplexScale4 = 1 / 0.03673
+)
+
+// openSimplexGrad2 holds 24 unit directions, half a step off the axes
+var openSimplexGrad2 = func() (g [24][2]float32) {
+	for i := range g {
+		a := (float64(i) + 0.5) * 2 * math.Pi / 24
+		g[i] = [2]float32{float32(math.Cos(a)), float32(math.Sin(a))}
+	}
+	return g
+}()
+
+// openSimplexGrad3 holds the 48 directions of OpenSimplex2, the signed permutations of (a, a, 1) and (b, c, 0), all
+// of the same length
+var openSimplexGrad3 = func() (g [48][3]float32) {
+	const a, b, c = 2.22474487139, 3.0862664687972017, 1.1721513422464978
+	n := 0
+	for axis := range 3 {
+		for signs := range 8 {
+			var v [3]float32
+			for k := range 3 {
+				v[k] = a
+				if k == axis {
+					v[k] = 1
+				}
+				if signs>>k&1 != 0 {
+					v[k] = -v[k]
+				}
+			}
+			g[n] = v
+			n++
+		}
+	}
+	for long := range 3 {
+		for short := range 3 {
+			if short == long {
+				continue
+			}
+			for signs := range 4 {
+				var v [3]float32
+				v[long], v[short] = b, c
+				if signs&1 != 0 {
+					v[long] = -v[long]
+				}
+				if signs&2 != 0 {
+					v[short] = -v[short]
+				}
+				g[n] = v
+				n++
+			}
+		}
+	}
+	return g
+}()
+
+func (o *OpenSimplex) Noise2(x, y float32) float32 {
+	sk := (x + y) * simplexF2
+	i := floorInt(x + sk)
+	j := floorInt(y + sk)
+	t := float32(i+j) * simplexG2
+
+	x0 := x - (float32(i) - t)
+	y0 := y - (float32(j) - t)
+
+	// with r² = 0.5 only the corners of the containing triangle are in reach
+	i1, j1 := 0, 1
+	if x0 > y0 {
+		i1, j1 = 1, 0
+	}
+
+	n := o.corner2(i, j, x0, y0) +
+		o.corner2(i+i1, j+j1, x0-float32(i1)+simplexG2, y0-float32(j1)+simplexG2) +
+		o.corner2(i+1, j+1, x0-1+2*simplexG2, y0-1+2*simplexG2)
+	return openSimplexScale2 * n
+}
+
+func (o *OpenSimplex) corner2(i, j int, x, y float32) float32 {
+	t := 0.5 - x*x - y*y
+	if t <= 0 {
+		return 0
+	}
+	t *= t
+	g := &openSimplexGrad2[int(o.perm[i&255+int(o.perm[j&255])])%len(openSimplexGrad2)]
+	return t * t * (g[0]*x + g[1]*y)
+}
+
+func (o *OpenSimplex) Noise3(x, y, z float32) float32 {
+	// reflect the input so the lattice's main diagonal, along which it looks most regular, is not aligned with
+	// any axis of the input
+	r := (x + y + z) * (2.0 / 3.0)
+	xr, yr, zr := r-x, r-y, r-z
+
+	// the body centred cubic lattice is two cubic lattices, the second offset by half a cell. A point of either is
+	// only in reach (r² = 0.6) from inside one of the eight cells around it
+	var n float32
+	for lattice := range 2 {
+		off := float32(lattice) * 0.5
+		bx, by, bz := floorInt(xr-off), floorInt(yr-off), floorInt(zr-off)
+		for corner := range 8 {
+			cx, cy, cz := bx+corner&1, by+corner>>1&1, bz+corner>>2
+			n += o.corner3(2*cx+lattice, 2*cy+lattice, 2*cz+lattice,
+				xr-float32(cx)-off, yr-float32(cy)-off, zr-float32(cz)-off)
+		}
+	}
+	return openSimplexScale3 * n
+}
+
+// corner3 takes the lattice point in half cells, so both cubic lattices hash apart
+func (o *OpenSimplex) corner3(i, j, k int, x, y, z float32) float32 {
+	t := 0.6 - x*x - y*y - z*z
+	if t <= 0 {
+		return 0
+	}
+	t *= t
+	pm := &o.perm
+	g := &openSimplexGrad3[int(pm[i&255+int(pm[j&255+int(pm[k&255])])])%len(openSimplexGrad3)]
+	return t * t * (g[0]*x + g[1]*y + g[2]*z)
+}
+
+func (o *OpenSimplex) Noise4(x, y, z, w float32) float32 {
+	// the A4* lattice is five copies of a skewed hypercubic lattice, each shifted a fifth of a cell along the
+	// diagonal. A point of a copy is only in reach (r² = 0.6) from inside one of the sixteen cells around it
+	sk := (x + y + z + w) * -simplexG4
+	xs, ys, zs, ws := x+sk, y+sk, z+sk, w+sk
+
+	var n float32
+	for lattice := range 5 {
+		off := float32(lattice) * 0.2
+		bx, by, bz, bw := floorInt(xs+off), floorInt(ys+off), floorInt(zs+off), floorInt(ws+off)
+		for corner := range 16 {
+			cx, cy, cz, cw := bx+corner&1, by+corner>>1&1, bz+corner>>2&1, bw+corner>>3
+			px, py, pz, pw := float32(cx)-off, float32(cy)-off, float32(cz)-off, float32(cw)-off
+			u := (px + py + pz + pw) * simplexF4
+			n += o.corner4(cx, cy, cz, cw, lattice, x-(px+u), y-(py+u), z-(pz+u), w-(pw+u))
+		}
+	}
+	return openSimplexScale4 * n
+}
+
+func (o *OpenSimplex) corner4(i, j, k, l, lattice int, x, y, z, w float32) float32 {
+	t := 0.6 - x*x - y*y - z*z - w*w
+	if t <= 0 {
+		return 0
+	}
+	t *= t
+	pm := &o.perm
+	h := pm[i&255+int(pm[j&255+int(pm[k&255+int(pm[l&255+int(pm[lattice])])])])]
+	return t * t * grad4(h, x, y, z, w)
+}
@@ -0,0 +1,78 @@
+package notamath
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// openSimplexSamples returns n random points in a cube of the given size, the same ones on every call
+func openSimplexSamples(n int, size float32) [][4]float32 {
+	rng := rand.New(rand.NewSource(1))
+	pts := make([][4]float32, n)
+	for i := range pts {
+		for k := range pts[i] {
+			pts[i][k] = (rng.Float32()*2 - 1) * size
+		}
+	}
+	return pts
+}
+
+func openSimplexNoises(o *OpenSimplex, p [4]float32) [3]float32 {
+	return [3]float32{o.Noise2(p[0], p[1]), o.Noise3(p[0], p[1], p[2]), o.Noise4(p[0], p[1], p[2], p[3])}
+}
+
+func TestOpenSimplexRange(t *testing.T) {
+	o := NewOpenSimplex(7)
+	var lo, hi [3]float32
+	for _, p := range openSimplexSamples(200000, 100) {
+		for d, v := range openSimplexNoises(o, p) {
+			if v < -1.01 || v > 1.01 {
+				t.Fatalf("Noise%d%v = %f, outside [-1, 1]", d+2, p, v)
+			}
+			lo[d], hi[d] = min(lo[d], v), max(hi[d], v)
+		}
+	}
+	// the scale should use most of the range, not leave it half empty
+	for d := range lo {
+		if lo[d] > -0.6 || hi[d] < 0.6 {
+			t.Errorf("Noise%d only spans [%f, %f]", d+2, lo[d], hi[d])
+		}
+	}
+}
+
+func TestOpenSimplexContinuous(t *testing.T) {
+	// a lattice point cut off at a cell border would show up as a jump between close samples
+	o := NewOpenSimplex(3)
+	const eps = 1e-3
+	for _, p := range openSimplexSamples(100000, 50) {
+		a := openSimplexNoises(o, p)
+		for axis := range p {
+			q := p
+			q[axis] += eps
+			b := openSimplexNoises(o, q)
+			for d := range a {
+				if axis > d+1 {
+					continue
+				}
+				if diff := b[d] - a[d]; diff > 20*eps || diff < -20*eps {
+					t.Fatalf("Noise%d jumps by %f between %v and %v", d+2, diff, p, q)
+				}
+			}
+		}
+	}
+}
+
+func TestOpenSimplexSeeded(t *testing.T) {
+	a, b, c := NewOpenSimplex(1), NewOpenSimplex(1), NewOpenSimplex(2)
+	differs := false
+	for _, p := range openSimplexSamples(100, 10) {
+		na, nb, nc := openSimplexNoises(a, p), openSimplexNoises(b, p), openSimplexNoises(c, p)
+		if na != nb {
+			t.Fatalf("same seed gave %v and %v at %v", na, nb, p)
+		}
+		differs = differs || na != nc
+	}
+	if !differs {
+		t.Error("different seeds gave the same noise")
+	}
+}
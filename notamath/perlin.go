@@ -0,0 +1,199 @@
+package notamath
+
+import "math"
+
+// Perlin is seedable gradient noise, every NoiseN returns values roughly in [-1, 1]
+type Perlin struct {
+	perm [512]uint8
+}
+
+func NewPerlin(seed uint64) *Perlin {
+	p := &Perlin{}
+	p.perm = permutation(seed)
+	return p
+}
+
+// permutation returns a seeded shuffle of 0..255, repeated twice to avoid wrapping indices
+func permutation(seed uint64) [512]uint8 {
+	var perm [512]uint8
+	for i := 0; i < 256; i++ {
+		perm[i] = uint8(i)
+	}
+
+	state := seed
+	for i := 255; i > 0; i-- {
+		j := int(splitMix64(&state) % uint64(i+1))
+		perm[i], perm[j] = perm[j], perm[i]
+	}
+
+	copy(perm[256:], perm[:256])
+	return perm
+}
+
+func splitMix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (p *Perlin) Noise2(x, y float32) float32 {
+	xi, xf := floorSplit(x)
+	yi, yf := floorSplit(y)
+	u, v := fade(xf), fade(yf)
+
+	pm := &p.perm
+	aa := pm[int(pm[xi])+yi]
+	ab := pm[int(pm[xi])+yi+1]
+	ba := pm[int(pm[xi+1])+yi]
+	bb := pm[int(pm[xi+1])+yi+1]
+
+	n := lerp(
+		lerp(grad2(aa, xf, yf), grad2(ba, xf-1, yf), u),
+		lerp(grad2(ab, xf, yf-1), grad2(bb, xf-1, yf-1), u),
+		v,
+	)
+	return n * 1.25
+}
+
+func (p *Perlin) Noise3(x, y, z float32) float32 {
+	xi, xf := floorSplit(x)
+	yi, yf := floorSplit(y)
+	zi, zf := floorSplit(z)
+	u, v, w := fade(xf), fade(yf), fade(zf)
+
+	pm := &p.perm
+	a := int(pm[xi]) + yi
+	aa := int(pm[a]) + zi
+	ab := int(pm[a+1]) + zi
+	b := int(pm[xi+1]) + yi
+	ba := int(pm[b]) + zi
+	bb := int(pm[b+1]) + zi
+
+	return lerp(
+		lerp(
+			lerp(grad3(pm[aa], xf, yf, zf), grad3(pm[ba], xf-1, yf, zf), u),
+			lerp(grad3(pm[ab], xf, yf-1, zf), grad3(pm[bb], xf-1, yf-1, zf), u),
+			v,
+		),
+		lerp(
+			lerp(grad3(pm[aa+1], xf, yf, zf-1), grad3(pm[ba+1], xf-1, yf, zf-1), u),
+			lerp(grad3(pm[ab+1], xf, yf-1, zf-1), grad3(pm[bb+1], xf-1, yf-1, zf-1), u),
+			v,
+		),
+		w,
+	)
+}
+
+func (p *Perlin) Noise4(x, y, z, w float32) float32 {
+	xi, xf := floorSplit(x)
+	yi, yf := floorSplit(y)
+	zi, zf := floorSplit(z)
+	wi, wf := floorSplit(w)
+	fx, fy, fz, fw := fade(xf), fade(yf), fade(zf), fade(wf)
+
+	pm := &p.perm
+	hash := func(i, j, k, l int) uint8 {
+		return pm[int(pm[int(pm[int(pm[xi+i])+yi+j])+zi+k])+wi+l]
+	}
+	corner := func(i, j, k, l int) float32 {
+		return grad4(hash(i, j, k, l), xf-float32(i), yf-float32(j), zf-float32(k), wf-float32(l))
+	}
+	cube := func(l int) float32 {
+		return lerp(
+			lerp(
+				lerp(corner(0, 0, 0, l), corner(1, 0, 0, l), fx),
+				lerp(corner(0, 1, 0, l), corner(1, 1, 0, l), fx),
+				fy,
+			),
+			lerp(
+				lerp(corner(0, 0, 1, l), corner(1, 0, 1, l), fx),
+				lerp(corner(0, 1, 1, l), corner(1, 1, 1, l), fx),
+				fy,
+			),
+			fz,
+		)
+	}
+
+	return lerp(cube(0), cube(1), fw) * 0.87
+}
+
+// floorSplit returns the lattice cell wrapped to 0..255 and the fractional position inside it
+func floorSplit(v float32) (int, float32) {
+	f := float32(math.Floor(float64(v)))
+	return int(f) & 255, v - f
+}
+
+func fade(t float32) float32 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(a, b, t float32) float32 {
+	return a + (b-a)*t
+}
+
+func grad2(h uint8, x, y float32) float32 {
+	switch h & 7 {
+	case 0:
+		return x + y
+	case 1:
+		return -x + y
+	case 2:
+		return x - y
+	case 3:
+		return -x - y
+	case 4:
+		return x
+	case 5:
+		return -x
+	case 6:
+		return y
+	default:
+		return -y
+	}
+}
+
+func grad3(h uint8, x, y, z float32) float32 {
+	h &= 15
+	u, v := y, z
+	if h < 8 {
+		u = x
+	}
+	if h < 4 {
+		v = y
+	} else if h == 12 || h == 14 {
+		v = x
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
+
+func grad4(h uint8, x, y, z, w float32) float32 {
+	h &= 31
+	a, b, c := y, z, w
+	if h < 24 {
+		a = x
+	}
+	if h < 16 {
+		b = y
+	}
+	if h < 8 {
+		c = z
+	}
+	if h&1 != 0 {
+		a = -a
+	}
+	if h&2 != 0 {
+		b = -b
+	}
+	if h&4 != 0 {
+		c = -c
+	}
+	return a + b + c
+}
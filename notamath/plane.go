@@ -0,0 +1,55 @@
+package notamath
+
+// Plane holds every point p where Normal.Dot(p) + D == 0
+type Plane struct {
+	Normal Vec3
+	D      float32
+}
+
+func PlaneFromPointNormal(p Po3, normal Vec3) Plane {
+	n := normal.Normalize()
+	return Plane{Normal: n, D: -n.Dot(Vec3(p))}
+}
+
+// PlaneFromPoints builds the plane through three points, the normal follows counter-clockwise winding
+func PlaneFromPoints(a, b, c Po3) Plane {
+	n := b.SubPo(a).Cross(c.SubPo(a))
+	return PlaneFromPointNormal(a, n)
+}
+
+// Normalize rescales the plane so that the normal has unit length
+func (p Plane) Normalize() Plane {
+	l := p.Normal.Len()
+	if l == 0 {
+		return p
+	}
+	return Plane{Normal: p.Normal.Div(l), D: p.D / l}
+}
+
+// SignedDistance is positive on the side the normal points to, assumes a normalized plane
+func (p Plane) SignedDistance(pt Po3) float32 {
+	return p.Normal.Dot(Vec3(pt)) + p.D
+}
+
+func (p Plane) Distance(pt Po3) float32 {
+	return absf(p.SignedDistance(pt))
+}
+
+func (p Plane) ClosestPoint(pt Po3) Po3 {
+	return pt.Add(p.Normal.Mul(-p.SignedDistance(pt)))
+}
+
+// IntersectSegment returns the point where the segment crosses the plane
+func (p Plane) IntersectSegment(s Segment3) (Po3, bool) {
+	ab := s.B.SubPo(s.A)
+	denom := p.Normal.Dot(ab)
+	if absf(denom) < geomEpsilon {
+		return Po3{}, false
+	}
+
+	t := -p.SignedDistance(s.A) / denom
+	if t < 0 || t > 1 {
+		return Po3{}, false
+	}
+	return s.A.Add(ab.Mul(t)), true
+}
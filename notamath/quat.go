@@ -0,0 +1,254 @@
+package notamath
+
+import (
+	"fmt"
+	"math"
+)
+
+// Quat is a rotation quaternion, W is the scalar part
+type Quat struct {
+	X, Y, Z, W float32
+}
+
+func QuatIdentity() Quat {
+	return Quat{0, 0, 0, 1}
+}
+
+func QuatFromAxisAngle(axis Vec3, angle float32) Quat {
+	k := axis.Normalize()
+	if k == (Vec3{}) {
+		return QuatIdentity()
+	}
+
+	half := float64(angle) * 0.5
+	s := float32(math.Sin(half))
+	c := float32(math.Cos(half))
+
+	return Quat{k.X * s, k.Y * s, k.Z * s, c}
+}
+
+// QuatFromEuler builds a rotation from euler angles in radians, applied roll (Z), then pitch (X), then yaw (Y)
+func QuatFromEuler(pitch, yaw, roll float32) Quat {
+	qx := QuatFromAxisAngle(Vec3{1, 0, 0}, pitch)
+	qy := QuatFromAxisAngle(Vec3{0, 1, 0}, yaw)
+	qz := QuatFromAxisAngle(Vec3{0, 0, 1}, roll)
+
+	return qy.Mul(qx).Mul(qz)
+}
+
+// QuatLookRotation returns the rotation that points the -Z axis along forward, keeping +Y as close to up as possible.
+// It matches the camera convention used by Mat4LookAt
+func QuatLookRotation(forward Vec3, up Vec3) Quat {
+	f := forward.Normalize()
+	if f == (Vec3{}) {
+		return QuatIdentity()
+	}
+
+	s := f.Cross(up).Normalize()
+	if s == (Vec3{}) {
+		// forward and up are parallel, pick any perpendicular right vector
+		s = f.Cross(Vec3{1, 0, 0}).Normalize()
+		if s == (Vec3{}) {
+			s = f.Cross(Vec3{0, 0, 1}).Normalize()
+		}
+	}
+	u := s.Cross(f)
+
+	// columns are right, up and back (-forward)
+	return quatFromBasis(
+		s.X, u.X, -f.X,
+		s.Y, u.Y, -f.Y,
+		s.Z, u.Z, -f.Z,
+	)
+}
+
+// QuatFromMat4 extracts the rotation of the upper-left 3x3 part of the matrix, which must be free of scale
+func QuatFromMat4(m Mat4) Quat {
+	return quatFromBasis(
+		m.M[0], m.M[1], m.M[2],
+		m.M[4], m.M[5], m.M[6],
+		m.M[8], m.M[9], m.M[10],
+	)
+}
+
+func quatFromBasis(m00, m01, m02, m10, m11, m12, m20, m21, m22 float32) Quat {
+	var q Quat
+
+	trace := m00 + m11 + m22
+	switch {
+	case trace > 0:
+		s := float32(math.Sqrt(float64(trace+1))) * 2
+		q = Quat{
+			X: (m21 - m12) / s,
+			Y: (m02 - m20) / s,
+			Z: (m10 - m01) / s,
+			W: 0.25 * s,
+		}
+	case m00 > m11 && m00 > m22:
+		s := float32(math.Sqrt(float64(1+m00-m11-m22))) * 2
+		q = Quat{
+			X: 0.25 * s,
+			Y: (m01 + m10) / s,
+			Z: (m02 + m20) / s,
+			W: (m21 - m12) / s,
+		}
+	case m11 > m22:
+		s := float32(math.Sqrt(float64(1+m11-m00-m22))) * 2
+		q = Quat{
+			X: (m01 + m10) / s,
+			Y: 0.25 * s,
+			Z: (m12 + m21) / s,
+			W: (m02 - m20) / s,
+		}
+	default:
+		s := float32(math.Sqrt(float64(1+m22-m00-m11))) * 2
+		q = Quat{
+			X: (m02 + m20) / s,
+			Y: (m12 + m21) / s,
+			Z: 0.25 * s,
+			W: (m10 - m01) / s,
+		}
+	}
+
+	return q.Normalize()
+}
+
+// Mul combines two rotations, the result applies b first and then q
+func (q Quat) Mul(b Quat) Quat {
+	return Quat{
+		X: q.W*b.X + q.X*b.W + q.Y*b.Z - q.Z*b.Y,
+		Y: q.W*b.Y - q.X*b.Z + q.Y*b.W + q.Z*b.X,
+		Z: q.W*b.Z + q.X*b.Y - q.Y*b.X + q.Z*b.W,
+		W: q.W*b.W - q.X*b.X - q.Y*b.Y - q.Z*b.Z,
+	}
+}
+
+func (q Quat) Dot(b Quat) float32 {
+	return q.X*b.X + q.Y*b.Y + q.Z*b.Z + q.W*b.W
+}
+
+func (q Quat) LenSquared() float32 {
+	return q.Dot(q)
+}
+
+func (q Quat) Len() float32 {
+	return float32(math.Sqrt(float64(q.LenSquared())))
+}
+
+// Normalize returns the unit quaternion, a zero quaternion becomes the identity
+func (q Quat) Normalize() Quat {
+	l := q.Len()
+	if l == 0 {
+		return QuatIdentity()
+	}
+	inv := 1 / l
+	return Quat{q.X * inv, q.Y * inv, q.Z * inv, q.W * inv}
+}
+
+func (q Quat) Conjugate() Quat {
+	return Quat{-q.X, -q.Y, -q.Z, q.W}
+}
+
+func (q Quat) Inverse() Quat {
+	l := q.LenSquared()
+	if l == 0 {
+		return QuatIdentity()
+	}
+	inv := 1 / l
+	return Quat{-q.X * inv, -q.Y * inv, -q.Z * inv, q.W * inv}
+}
+
+func (q Quat) Neg() Quat {
+	return Quat{-q.X, -q.Y, -q.Z, -q.W}
+}
+
+// Rotate applies the rotation to a vector
+func (q Quat) Rotate(v Vec3) Vec3 {
+	u := Vec3{q.X, q.Y, q.Z}
+	t := u.Cross(v).Mul(2)
+	return v.Add(t.Mul(q.W)).Add(u.Cross(t))
+}
+
+// AxisAngle returns the rotation axis and angle in radians, the axis is X when there is no rotation
+func (q Quat) AxisAngle() (Vec3, float32) {
+	q = q.Normalize()
+	if q.W < 0 {
+		q = q.Neg()
+	}
+
+	s := float32(math.Sqrt(float64(1 - q.W*q.W)))
+	angle := 2 * float32(math.Acos(float64(min(q.W, 1))))
+	if s < 1e-6 {
+		return Vec3{1, 0, 0}, 0
+	}
+	return Vec3{q.X / s, q.Y / s, q.Z / s}, angle
+}
+
+// Nlerp linearly interpolates along the shortest path and normalizes, cheaper than Slerp but not constant speed
+func (q Quat) Nlerp(to Quat, t float32) Quat {
+	if q.Dot(to) < 0 {
+		to = to.Neg()
+	}
+	return Quat{
+		X: q.X + (to.X-q.X)*t,
+		Y: q.Y + (to.Y-q.Y)*t,
+		Z: q.Z + (to.Z-q.Z)*t,
+		W: q.W + (to.W-q.W)*t,
+	}.Normalize()
+}
+
+// Slerp spherically interpolates along the shortest path with constant angular speed
+func (q Quat) Slerp(to Quat, t float32) Quat {
+	q = q.Normalize()
+	to = to.Normalize()
+
+	cos := q.Dot(to)
+	if cos < 0 {
+		to = to.Neg()
+		cos = -cos
+	}
+
+	// nearly identical rotations, avoid dividing by a tiny sine
+	if cos > 0.9995 {
+		return q.Nlerp(to, t)
+	}
+
+	theta := math.Acos(float64(cos))
+	sin := math.Sin(theta)
+	wa := float32(math.Sin((1-float64(t))*theta) / sin)
+	wb := float32(math.Sin(float64(t)*theta) / sin)
+
+	return Quat{
+		X: q.X*wa + to.X*wb,
+		Y: q.Y*wa + to.Y*wb,
+		Z: q.Z*wa + to.Z*wb,
+		W: q.W*wa + to.W*wb,
+	}
+}
+
+// Mat4 returns the rotation matrix of a unit quaternion
+func (q Quat) Mat4() Mat4 {
+	xx, yy, zz := q.X*q.X, q.Y*q.Y, q.Z*q.Z
+	xy, xz, yz := q.X*q.Y, q.X*q.Z, q.Y*q.Z
+	wx, wy, wz := q.W*q.X, q.W*q.Y, q.W*q.Z
+
+	return Mat4{M: [16]float32{
+		1 - 2*(yy+zz), 2 * (xy - wz), 2 * (xz + wy), 0,
+		2 * (xy + wz), 1 - 2*(xx+zz), 2 * (yz - wx), 0,
+		2 * (xz - wy), 2 * (yz + wx), 1 - 2*(xx+yy), 0,
+		0, 0, 0, 1,
+	}}
+}
+
+func (q Quat) Equals(b Quat, eps float32) bool {
+	// q and -q describe the same rotation
+	d := q.Dot(b)
+	if d < 0 {
+		d = -d
+	}
+	return floatEqual(d, q.Len()*b.Len(), eps)
+}
+
+func (q Quat) String() string {
+	return fmt.Sprintf("Quat(%f, %f, %f, %f)", q.X, q.Y, q.Z, q.W)
+}
@@ -0,0 +1,325 @@
+package notamath
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// Rand is a PCG32 generator. It is small, fast and the same seed replays the same sequence for replays and lockstep
+// netcode. The integer methods, Float32, Float64, Range, Chance, WeightedChoice, InRect and InTriangle give bit
+// identical results on every platform, products are converted explicitly so the compiler cannot fuse them.
+// Gaussian goes through math.Log, OnUnitCircle, OnUnitSphere, InUnitSphere, InCircle and PoissonDisk through
+// math.Sin and math.Cos and InPolygon through Triangulate, those may differ in the last bit between architectures
+// so keep them out of lockstep state. It is not safe for concurrent use
+type Rand struct {
+	state uint64
+	inc   uint64
+}
+
+// RandState is the complete state of a Rand, save it to resume the exact sequence later
+type RandState struct {
+	State uint64
+	Inc   uint64
+}
+
+const pcgMultiplier = 6364136223846793005
+
+func NewRand(seed uint64) *Rand {
+	return NewRandStream(seed, 0xda3e39cb94b95bdb)
+}
+
+// NewRandStream seeds a generator on one of 2^63 independent streams, generators with the same seed
+// but different streams produce unrelated sequences
+func NewRandStream(seed, stream uint64) *Rand {
+	r := &Rand{inc: stream<<1 | 1}
+	r.Uint32()
+	r.state += seed
+	r.Uint32()
+	return r
+}
+
+func (r *Rand) State() RandState {
+	return RandState{State: r.state, Inc: r.inc}
+}
+
+func (r *Rand) SetState(s RandState) {
+	r.state = s.State
+	r.inc = s.Inc | 1
+}
+
+func (r *Rand) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint64(buf[:8], r.state)
+	binary.LittleEndian.PutUint64(buf[8:], r.inc)
+	return buf, nil
+}
+
+func (r *Rand) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return errors.New("invalid rand state length")
+	}
+	r.SetState(RandState{
+		State: binary.LittleEndian.Uint64(data[:8]),
+		Inc:   binary.LittleEndian.Uint64(data[8:]),
+	})
+	return nil
+}
+
+func (r *Rand) Uint32() uint32 {
+	old := r.state
+	r.state = old*pcgMultiplier + r.inc
+	xorShifted := uint32(((old >> 18) ^ old) >> 27)
+	rot := int(old >> 59)
+	return bits.RotateLeft32(xorShifted, -rot)
+}
+
+func (r *Rand) Uint64() uint64 {
+	return uint64(r.Uint32())<<32 | uint64(r.Uint32())
+}
+
+// Intn returns an unbiased integer in [0, n), it panics if n <= 0
+func (r *Rand) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	if n <= math.MaxUint32 {
+		return int(r.uint32n(uint32(n)))
+	}
+
+	// rejection sampling for large n
+	limit := math.MaxUint64 - math.MaxUint64%uint64(n)
+	for {
+		v := r.Uint64()
+		if v < limit {
+			return int(v % uint64(n))
+		}
+	}
+}
+
+// uint32n is Lemire's multiply and reject method
+func (r *Rand) uint32n(n uint32) uint32 {
+	m := uint64(r.Uint32()) * uint64(n)
+	if low := uint32(m); low < n {
+		threshold := -n % n
+		for low < threshold {
+			m = uint64(r.Uint32()) * uint64(n)
+			low = uint32(m)
+		}
+	}
+	return uint32(m >> 32)
+}
+
+// IntRange returns an integer in [lo, hi], both ends included
+func (r *Rand) IntRange(lo, hi int) int {
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	return lo + r.Intn(hi-lo+1)
+}
+
+// Float32 returns a float in [0, 1)
+func (r *Rand) Float32() float32 {
+	return float32(r.Uint32()>>8) / (1 << 24)
+}
+
+func (r *Rand) Float64() float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
+
+// Range returns a float in [lo, hi)
+func (r *Rand) Range(lo, hi float32) float32 {
+	return lo + float32((hi-lo)*r.Float32())
+}
+
+func (r *Rand) Bool() bool {
+	return r.Uint32()&1 == 1
+}
+
+// Chance returns true with probability p
+func (r *Rand) Chance(p float32) bool {
+	return r.Float32() < p
+}
+
+// WeightedChoice returns an index picked with probability proportional to its weight, -1 if all weights are zero
+func (r *Rand) WeightedChoice(weights []float32) int {
+	var total float32
+	for _, w := range weights {
+		total += max(w, 0)
+	}
+	if total <= 0 {
+		return -1
+	}
+
+	pick := float32(r.Float32() * total)
+	last := -1
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		last = i
+		if pick < w {
+			return i
+		}
+		pick -= w
+	}
+	// float rounding can walk past the end
+	return last
+}
+
+// Shuffle randomizes the order of n elements with Fisher-Yates
+func (r *Rand) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		swap(i, r.Intn(i+1))
+	}
+}
+
+// Gaussian returns a normally distributed value (Marsaglia polar method)
+func (r *Rand) Gaussian(mean, stddev float32) float32 {
+	for {
+		u := r.Float64()*2 - 1
+		v := r.Float64()*2 - 1
+		s := float64(u*u) + float64(v*v)
+		if s > 0 && s < 1 {
+			return mean + float32(stddev*float32(u*math.Sqrt(-2*math.Log(s)/s)))
+		}
+	}
+}
+
+// OnUnitCircle returns a random unit vector
+func (r *Rand) OnUnitCircle() Vec2 {
+	a := float64(r.Float32()) * 2 * math.Pi
+	return Vec2{float32(math.Cos(a)), float32(math.Sin(a))}
+}
+
+// OnUnitSphere returns a random unit vector, uniformly distributed over the sphere
+func (r *Rand) OnUnitSphere() Vec3 {
+	z := r.Range(-1, 1)
+	a := float64(r.Float32()) * 2 * math.Pi
+	s := float32(math.Sqrt(float64(1 - float32(z*z))))
+	return Vec3{s * float32(math.Cos(a)), s * float32(math.Sin(a)), z}
+}
+
+// InUnitSphere returns a random vector inside the unit sphere
+func (r *Rand) InUnitSphere() Vec3 {
+	radius := float32(math.Cbrt(float64(r.Float32())))
+	return r.OnUnitSphere().Mul(radius)
+}
+
+// InCircle returns a uniformly distributed point inside the circle
+func (r *Rand) InCircle(center Po2, radius float32) Po2 {
+	d := radius * float32(math.Sqrt(float64(r.Float32())))
+	return center.Add(r.OnUnitCircle().Mul(d))
+}
+
+func (r *Rand) InRect(rect Rect) Po2 {
+	return Po2{r.Range(rect.Min.X, rect.Max.X), r.Range(rect.Min.Y, rect.Max.Y)}
+}
+
+// InTriangle returns a uniformly distributed point inside the triangle
+func (r *Rand) InTriangle(a, b, c Po2) Po2 {
+	u, v := r.Float32(), r.Float32()
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+	return Po2{
+		a.X + float32((b.X-a.X)*u) + float32((c.X-a.X)*v),
+		a.Y + float32((b.Y-a.Y)*u) + float32((c.Y-a.Y)*v),
+	}
+}
+
+// InPolygon returns a uniformly distributed point inside a simple polygon, triangles are picked by area
+func (r *Rand) InPolygon(poly []Po2) (Po2, bool) {
+	tris := Triangulate(poly)
+	if len(tris) == 0 {
+		return Po2{}, false
+	}
+
+	areas := make([]float32, len(tris)/3)
+	for i := range areas {
+		areas[i] = Orient(tris[i*3], tris[i*3+1], tris[i*3+2])
+	}
+
+	i := r.WeightedChoice(areas)
+	if i < 0 {
+		return Po2{}, false
+	}
+	return r.InTriangle(tris[i*3], tris[i*3+1], tris[i*3+2]), true
+}
+
+// PoissonDisk fills the rectangle with points that are at least radius apart (Bridson's algorithm),
+// attempts is the number of candidates tried around each point, 30 is a common value
+func (r *Rand) PoissonDisk(area Rect, radius float32, attempts int) []Po2 {
+	if radius <= 0 || area.Width() <= 0 || area.Height() <= 0 {
+		return nil
+	}
+	if attempts <= 0 {
+		attempts = 30
+	}
+
+	cell := radius / float32(math.Sqrt2)
+	cols := int(math.Ceil(float64(area.Width() / cell)))
+	rows := int(math.Ceil(float64(area.Height() / cell)))
+	grid := make([]int, cols*rows)
+	for i := range grid {
+		grid[i] = -1
+	}
+
+	cellOf := func(p Po2) (int, int) {
+		cx := min(int((p.X-area.Min.X)/cell), cols-1)
+		cy := min(int((p.Y-area.Min.Y)/cell), rows-1)
+		return cx, cy
+	}
+
+	var points []Po2
+	var active []int
+
+	add := func(p Po2) {
+		cx, cy := cellOf(p)
+		grid[cy*cols+cx] = len(points)
+		active = append(active, len(points))
+		points = append(points, p)
+	}
+
+	fits := func(p Po2) bool {
+		if !area.Contains(p) {
+			return false
+		}
+		cx, cy := cellOf(p)
+		for y := max(cy-2, 0); y <= min(cy+2, rows-1); y++ {
+			for x := max(cx-2, 0); x <= min(cx+2, cols-1); x++ {
+				if i := grid[y*cols+x]; i >= 0 && points[i].DistanceSquared(p) < radius*radius {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	add(r.InRect(area))
+
+	for len(active) > 0 {
+		k := r.Intn(len(active))
+		origin := points[active[k]]
+
+		found := false
+		for i := 0; i < attempts; i++ {
+			// candidate in the annulus between radius and 2 * radius
+			d := r.Range(radius, 2*radius)
+			candidate := origin.Add(r.OnUnitCircle().Mul(d))
+			if fits(candidate) {
+				add(candidate)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			active[k] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+
+	return points
+}
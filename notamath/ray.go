@@ -0,0 +1,161 @@
+package notamath
+
+import "math"
+
+// geomEpsilon is the tolerance used by the intersection queries to treat values as zero
+const geomEpsilon float32 = 1e-6
+
+// Ray2 starts at Origin and extends infinitely along Dir, Dir does not need to be normalized
+type Ray2 struct {
+	Origin Po2
+	Dir    Vec2
+}
+
+// Ray3 starts at Origin and extends infinitely along Dir, Dir does not need to be normalized
+type Ray3 struct {
+	Origin Po3
+	Dir    Vec3
+}
+
+func (r Ray2) At(t float32) Po2 {
+	return r.Origin.Add(r.Dir.Mul(t))
+}
+
+func (r Ray2) ClosestPoint(p Po2) Po2 {
+	d := r.Dir.LenSquared()
+	if d == 0 {
+		return r.Origin
+	}
+	t := max(p.Sub(r.Origin).Dot(r.Dir)/d, 0)
+	return r.At(t)
+}
+
+func (r Ray2) Distance(p Po2) float32 {
+	return r.ClosestPoint(p).Distance(p)
+}
+
+// IntersectSegment returns the ray parameter where it crosses the segment
+func (r Ray2) IntersectSegment(s Segment2) (float32, bool) {
+	e := s.B.Sub(s.A)
+	denom := r.Dir.Cross(e)
+	if absf(denom) < geomEpsilon {
+		return 0, false
+	}
+
+	ao := s.A.Sub(r.Origin)
+	t := ao.Cross(e) / denom
+	u := ao.Cross(r.Dir) / denom
+	if t < 0 || u < 0 || u > 1 {
+		return 0, false
+	}
+	return t, true
+}
+
+// IntersectRect returns the ray parameters where it enters and leaves the rectangle (slab test),
+// tmin is 0 when the origin is inside
+func (r Ray2) IntersectRect(b Rect) (tmin, tmax float32, ok bool) {
+	tmin, tmax = 0, float32(math.Inf(1))
+
+	if !slab(r.Origin.X, r.Dir.X, b.Min.X, b.Max.X, &tmin, &tmax) ||
+		!slab(r.Origin.Y, r.Dir.Y, b.Min.Y, b.Max.Y, &tmin, &tmax) {
+		return 0, 0, false
+	}
+	return tmin, tmax, true
+}
+
+func (r Ray3) At(t float32) Po3 {
+	return r.Origin.Add(r.Dir.Mul(t))
+}
+
+func (r Ray3) ClosestPoint(p Po3) Po3 {
+	d := r.Dir.LenSquared()
+	if d == 0 {
+		return r.Origin
+	}
+	t := max(p.SubPo(r.Origin).Dot(r.Dir)/d, 0)
+	return r.At(t)
+}
+
+func (r Ray3) Distance(p Po3) float32 {
+	return r.ClosestPoint(p).Distance(p)
+}
+
+// IntersectPlane returns the ray parameter where it hits the plane, rays parallel to the plane never hit
+func (r Ray3) IntersectPlane(p Plane) (float32, bool) {
+	denom := p.Normal.Dot(r.Dir)
+	if absf(denom) < geomEpsilon {
+		return 0, false
+	}
+
+	t := -p.SignedDistance(r.Origin) / denom
+	if t < 0 {
+		return 0, false
+	}
+	return t, true
+}
+
+// IntersectSphere returns the ray parameter of the first hit, 0 when the origin is inside the sphere
+func (r Ray3) IntersectSphere(s Sphere) (float32, bool) {
+	m := r.Origin.SubPo(s.Center)
+	a := r.Dir.LenSquared()
+	if a == 0 {
+		return 0, false
+	}
+	b := m.Dot(r.Dir)
+	c := m.LenSquared() - s.Radius*s.Radius
+
+	// origin outside and pointing away
+	if c > 0 && b > 0 {
+		return 0, false
+	}
+
+	disc := b*b - a*c
+	if disc < 0 {
+		return 0, false
+	}
+
+	t := (-b - float32(math.Sqrt(float64(disc)))) / a
+	return max(t, 0), true
+}
+
+// IntersectBox returns the ray parameters where it enters and leaves the box (slab test),
+// tmin is 0 when the origin is inside
+func (r Ray3) IntersectBox(b Box3) (tmin, tmax float32, ok bool) {
+	tmin, tmax = 0, float32(math.Inf(1))
+
+	if !slab(r.Origin.X, r.Dir.X, b.Min.X, b.Max.X, &tmin, &tmax) ||
+		!slab(r.Origin.Y, r.Dir.Y, b.Min.Y, b.Max.Y, &tmin, &tmax) ||
+		!slab(r.Origin.Z, r.Dir.Z, b.Min.Z, b.Max.Z, &tmin, &tmax) {
+		return 0, 0, false
+	}
+	return tmin, tmax, true
+}
+
+// slab narrows [tmin, tmax] to the part of the ray between lo and hi on one axis
+func slab(origin, dir, lo, hi float32, tmin, tmax *float32) bool {
+	if absf(dir) < geomEpsilon {
+		return origin >= lo && origin <= hi
+	}
+
+	inv := 1 / dir
+	t1 := (lo - origin) * inv
+	t2 := (hi - origin) * inv
+	if t1 > t2 {
+		t1, t2 = t2, t1
+	}
+
+	*tmin = max(*tmin, t1)
+	*tmax = min(*tmax, t2)
+	return *tmin <= *tmax
+}
+
+func absf(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func clamp(v, lo, hi float32) float32 {
+	return min(max(v, lo), hi)
+}
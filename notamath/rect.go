@@ -0,0 +1,68 @@
+package notamath
+
+// Rect is an axis aligned rectangle between Min and Max
+type Rect struct {
+	Min, Max Po2
+}
+
+func RectFromCenter(center Po2, w, h float32) Rect {
+	hw, hh := w/2, h/2
+	return Rect{
+		Min: Po2{center.X - hw, center.Y - hh},
+		Max: Po2{center.X + hw, center.Y + hh},
+	}
+}
+
+// RectFromPoints returns the smallest rectangle containing every point
+func RectFromPoints(points []Po2) Rect {
+	if len(points) == 0 {
+		return Rect{}
+	}
+
+	r := Rect{Min: points[0], Max: points[0]}
+	for _, p := range points[1:] {
+		r = r.Expand(p)
+	}
+	return r
+}
+
+func (r Rect) Width() float32  { return r.Max.X - r.Min.X }
+func (r Rect) Height() float32 { return r.Max.Y - r.Min.Y }
+
+func (r Rect) Center() Po2 {
+	return Po2{(r.Min.X + r.Max.X) / 2, (r.Min.Y + r.Max.Y) / 2}
+}
+
+func (r Rect) Contains(p Po2) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X &&
+		p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+func (r Rect) Intersects(o Rect) bool {
+	return r.Min.X <= o.Max.X && r.Max.X >= o.Min.X &&
+		r.Min.Y <= o.Max.Y && r.Max.Y >= o.Min.Y
+}
+
+// Expand grows the rectangle to include p
+func (r Rect) Expand(p Po2) Rect {
+	return Rect{
+		Min: Po2{min(r.Min.X, p.X), min(r.Min.Y, p.Y)},
+		Max: Po2{max(r.Max.X, p.X), max(r.Max.Y, p.Y)},
+	}
+}
+
+func (r Rect) Union(o Rect) Rect {
+	return r.Expand(o.Min).Expand(o.Max)
+}
+
+func (r Rect) ClosestPoint(p Po2) Po2 {
+	return Po2{
+		X: clamp(p.X, r.Min.X, r.Max.X),
+		Y: clamp(p.Y, r.Min.Y, r.Max.Y),
+	}
+}
+
+// Distance from the border of the rectangle, 0 for points inside
+func (r Rect) Distance(p Po2) float32 {
+	return r.ClosestPoint(p).Distance(p)
+}
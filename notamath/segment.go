@@ -0,0 +1,139 @@
+package notamath
+
+type Segment2 struct {
+	A, B Po2
+}
+
+type Segment3 struct {
+	A, B Po3
+}
+
+func (s Segment2) Length() float32 {
+	return s.A.Distance(s.B)
+}
+
+// ClosestT returns the parameter in [0, 1] of the point on the segment closest to p
+func (s Segment2) ClosestT(p Po2) float32 {
+	ab := s.B.Sub(s.A)
+	d := ab.LenSquared()
+	if d == 0 {
+		return 0
+	}
+	return clamp(p.Sub(s.A).Dot(ab)/d, 0, 1)
+}
+
+func (s Segment2) ClosestPoint(p Po2) Po2 {
+	return s.A.Add(s.B.Sub(s.A).Mul(s.ClosestT(p)))
+}
+
+func (s Segment2) Distance(p Po2) float32 {
+	return s.ClosestPoint(p).Distance(p)
+}
+
+// Intersect returns the crossing point of two segments, overlapping collinear segments return the first shared point of s
+func (s Segment2) Intersect(o Segment2) (Po2, bool) {
+	r := s.B.Sub(s.A)
+	q := o.B.Sub(o.A)
+	ao := o.A.Sub(s.A)
+
+	denom := r.Cross(q)
+	if absf(denom) < geomEpsilon {
+		if absf(ao.Cross(r)) >= geomEpsilon {
+			return Po2{}, false // parallel
+		}
+
+		// collinear, project o onto s and look for overlap
+		rr := r.LenSquared()
+		if rr == 0 {
+			if o.Distance(s.A) < geomEpsilon {
+				return s.A, true
+			}
+			return Po2{}, false
+		}
+		t0 := ao.Dot(r) / rr
+		t1 := o.B.Sub(s.A).Dot(r) / rr
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t1 < 0 || t0 > 1 {
+			return Po2{}, false
+		}
+		return s.A.Add(r.Mul(max(t0, 0))), true
+	}
+
+	t := ao.Cross(q) / denom
+	u := ao.Cross(r) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Po2{}, false
+	}
+	return s.A.Add(r.Mul(t)), true
+}
+
+func (s Segment3) Length() float32 {
+	return s.A.Distance(s.B)
+}
+
+// ClosestT returns the parameter in [0, 1] of the point on the segment closest to p
+func (s Segment3) ClosestT(p Po3) float32 {
+	ab := s.B.SubPo(s.A)
+	d := ab.LenSquared()
+	if d == 0 {
+		return 0
+	}
+	return clamp(p.SubPo(s.A).Dot(ab)/d, 0, 1)
+}
+
+func (s Segment3) ClosestPoint(p Po3) Po3 {
+	return s.A.Add(s.B.SubPo(s.A).Mul(s.ClosestT(p)))
+}
+
+func (s Segment3) Distance(p Po3) float32 {
+	return s.ClosestPoint(p).Distance(p)
+}
+
+// ClosestPoints returns the pair of closest points between two segments, the first on s and the second on o
+func (s Segment3) ClosestPoints(o Segment3) (Po3, Po3) {
+	d1 := s.B.SubPo(s.A)
+	d2 := o.B.SubPo(o.A)
+	r := s.A.SubPo(o.A)
+
+	a := d1.LenSquared()
+	e := d2.LenSquared()
+	f := d2.Dot(r)
+
+	var t, u float32
+	switch {
+	case a <= geomEpsilon && e <= geomEpsilon:
+		return s.A, o.A
+	case a <= geomEpsilon:
+		u = clamp(f/e, 0, 1)
+	default:
+		c := d1.Dot(r)
+		if e <= geomEpsilon {
+			t = clamp(-c/a, 0, 1)
+		} else {
+			b := d1.Dot(d2)
+			denom := a*e - b*b
+			if denom != 0 {
+				t = clamp((b*f-c*e)/denom, 0, 1)
+			}
+
+			u = (b*t + f) / e
+			if u < 0 {
+				u = 0
+				t = clamp(-c/a, 0, 1)
+			} else if u > 1 {
+				u = 1
+				t = clamp((b-c)/a, 0, 1)
+			}
+		}
+	}
+
+	return s.A.Add(d1.Mul(t)), o.A.Add(d2.Mul(u))
+}
+
+// SegmentDistance returns the shortest distance between two segments
+func (s Segment3) SegmentDistance(o Segment3) float32 {
+	p, q := s.ClosestPoints(o)
+	return p.Distance(q)
+}
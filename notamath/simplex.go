@@ -0,0 +1,212 @@
+package notamath
+
+import "math"
+
+// Simplex is seedable classic simplex noise (Gustavson's formulation), cheaper than Perlin in higher
+// dimensions and without its axis aligned artifacts. Its 3D and 4D kernels reach past the simplex they are summed
+// over, which leaves faint seams, OpenSimplex has none. Every NoiseN returns values roughly in [-1, 1]
+type Simplex struct {
+	perm [512]uint8
+}
+
+func NewSimplex(seed uint64) *Simplex {
+	s := &Simplex{}
+	s.perm = permutation(seed)
+	return s
+}
+
+var (
+	simplexF2 = float32(0.5 * (math.Sqrt(3) - 1))
+	simplexG2 = float32((3 - math.Sqrt(3)) / 6)
+	simplexF4 = float32((math.Sqrt(5) - 1) / 4)
+	simplexG4 = float32((5 - math.Sqrt(5)) / 20)
+)
+
+const (
+	simplexF3 = float32(1.0 / 3.0)
+	simplexG3 = float32(1.0 / 6.0)
+)
+
+func (s *Simplex) Noise2(x, y float32) float32 {
+	sk := (x + y) * simplexF2
+	i := floorInt(x + sk)
+	j := floorInt(y + sk)
+	t := float32(i+j) * simplexG2
+
+	x0 := x - (float32(i) - t)
+	y0 := y - (float32(j) - t)
+
+	i1, j1 := 0, 1
+	if x0 > y0 {
+		i1, j1 = 1, 0
+	}
+
+	x1 := x0 - float32(i1) + simplexG2
+	y1 := y0 - float32(j1) + simplexG2
+	x2 := x0 - 1 + 2*simplexG2
+	y2 := y0 - 1 + 2*simplexG2
+
+	ii, jj := i&255, j&255
+	pm := &s.perm
+
+	n := simplexCorner2(pm[ii+int(pm[jj])], x0, y0) +
+		simplexCorner2(pm[ii+i1+int(pm[jj+j1])], x1, y1) +
+		simplexCorner2(pm[ii+1+int(pm[jj+1])], x2, y2)
+	return 40 * n
+}
+
+func simplexCorner2(h uint8, x, y float32) float32 {
+	t := 0.5 - x*x - y*y
+	if t < 0 {
+		return 0
+	}
+	t *= t
+
+	// 8 gradient directions with one component doubled
+	h &= 7
+	u, v := y, x
+	if h < 4 {
+		u, v = x, y
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return t * t * (u + 2*v)
+}
+
+func (s *Simplex) Noise3(x, y, z float32) float32 {
+	sk := (x + y + z) * simplexF3
+	i := floorInt(x + sk)
+	j := floorInt(y + sk)
+	k := floorInt(z + sk)
+	t := float32(i+j+k) * simplexG3
+
+	x0 := x - (float32(i) - t)
+	y0 := y - (float32(j) - t)
+	z0 := z - (float32(k) - t)
+
+	// find which of the six tetrahedra of the cube the point is in
+	var i1, j1, k1, i2, j2, k2 int
+	if x0 >= y0 {
+		switch {
+		case y0 >= z0:
+			i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 1, 0
+		case x0 >= z0:
+			i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 0, 1
+		default:
+			i1, j1, k1, i2, j2, k2 = 0, 0, 1, 1, 0, 1
+		}
+	} else {
+		switch {
+		case y0 < z0:
+			i1, j1, k1, i2, j2, k2 = 0, 0, 1, 0, 1, 1
+		case x0 < z0:
+			i1, j1, k1, i2, j2, k2 = 0, 1, 0, 0, 1, 1
+		default:
+			i1, j1, k1, i2, j2, k2 = 0, 1, 0, 1, 1, 0
+		}
+	}
+
+	x1 := x0 - float32(i1) + simplexG3
+	y1 := y0 - float32(j1) + simplexG3
+	z1 := z0 - float32(k1) + simplexG3
+	x2 := x0 - float32(i2) + 2*simplexG3
+	y2 := y0 - float32(j2) + 2*simplexG3
+	z2 := z0 - float32(k2) + 2*simplexG3
+	x3 := x0 - 1 + 3*simplexG3
+	y3 := y0 - 1 + 3*simplexG3
+	z3 := z0 - 1 + 3*simplexG3
+
+	ii, jj, kk := i&255, j&255, k&255
+	pm := &s.perm
+	hash := func(a, b, c int) uint8 {
+		return pm[ii+a+int(pm[jj+b+int(pm[kk+c])])]
+	}
+
+	n := simplexCorner3(hash(0, 0, 0), x0, y0, z0) +
+		simplexCorner3(hash(i1, j1, k1), x1, y1, z1) +
+		simplexCorner3(hash(i2, j2, k2), x2, y2, z2) +
+		simplexCorner3(hash(1, 1, 1), x3, y3, z3)
+	return 32 * n
+}
+
+func simplexCorner3(h uint8, x, y, z float32) float32 {
+	t := 0.6 - x*x - y*y - z*z
+	if t < 0 {
+		return 0
+	}
+	t *= t
+	return t * t * grad3(h, x, y, z)
+}
+
+func (s *Simplex) Noise4(x, y, z, w float32) float32 {
+	sk := (x + y + z + w) * simplexF4
+	i := floorInt(x + sk)
+	j := floorInt(y + sk)
+	k := floorInt(z + sk)
+	l := floorInt(w + sk)
+	t := float32(i+j+k+l) * simplexG4
+
+	x0 := x - (float32(i) - t)
+	y0 := y - (float32(j) - t)
+	z0 := z - (float32(k) - t)
+	w0 := w - (float32(l) - t)
+
+	// rank the coordinates to find the simplex traversal order
+	var rx, ry, rz, rw int
+	rank := func(a, b float32, ra, rb *int) {
+		if a > b {
+			*ra++
+		} else {
+			*rb++
+		}
+	}
+	rank(x0, y0, &rx, &ry)
+	rank(x0, z0, &rx, &rz)
+	rank(x0, w0, &rx, &rw)
+	rank(y0, z0, &ry, &rz)
+	rank(y0, w0, &ry, &rw)
+	rank(z0, w0, &rz, &rw)
+
+	step := func(r, threshold int) int {
+		if r >= threshold {
+			return 1
+		}
+		return 0
+	}
+
+	ii, jj, kk, ll := i&255, j&255, k&255, l&255
+	pm := &s.perm
+	hash := func(a, b, c, d int) uint8 {
+		return pm[ii+a+int(pm[jj+b+int(pm[kk+c+int(pm[ll+d])])])]
+	}
+
+	n := simplexCorner4(hash(0, 0, 0, 0), x0, y0, z0, w0)
+	for c := 1; c <= 3; c++ {
+		threshold := 4 - c
+		a, b, cc, d := step(rx, threshold), step(ry, threshold), step(rz, threshold), step(rw, threshold)
+		g := float32(c) * simplexG4
+		n += simplexCorner4(hash(a, b, cc, d),
+			x0-float32(a)+g, y0-float32(b)+g, z0-float32(cc)+g, w0-float32(d)+g)
+	}
+	g := 4 * simplexG4
+	n += simplexCorner4(hash(1, 1, 1, 1), x0-1+g, y0-1+g, z0-1+g, w0-1+g)
+
+	return 27 * n
+}
+
+func simplexCorner4(h uint8, x, y, z, w float32) float32 {
+	t := 0.6 - x*x - y*y - z*z - w*w
+	if t < 0 {
+		return 0
+	}
+	t *= t
+	return t * t * grad4(h, x, y, z, w)
+}
+
+func floorInt(v float32) int {
+	return int(math.Floor(float64(v)))
+}
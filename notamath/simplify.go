@@ -0,0 +1,259 @@
+package notamath
+
+import "container/heap"
+
+// SimplifyDouglasPeucker drops points closer than tolerance to the simplified line (Ramer-Douglas-Peucker).
+// Closed rings are treated as wrapping around and keep at least three points
+func SimplifyDouglasPeucker(points []Po2, tolerance float32, closed bool) []Po2 {
+	n := len(points)
+	if n < 3 {
+		return append([]Po2(nil), points...)
+	}
+
+	keep := make([]bool, n)
+	if !closed {
+		keep[0], keep[n-1] = true, true
+		douglasPeucker(points, 0, n-1, tolerance, keep)
+		return keptPoints(points, keep)
+	}
+
+	// split the ring at the point furthest from the first one and simplify both halves
+	far := 0
+	for i, p := range points {
+		if p.DistanceSquared(points[0]) > points[far].DistanceSquared(points[0]) {
+			far = i
+		}
+	}
+	if far == 0 {
+		// every point coincides, there is no chord to simplify against
+		return append([]Po2(nil), points...)
+	}
+
+	keep[0], keep[far] = true, true
+	douglasPeucker(points, 0, far, tolerance, keep)
+
+	ring := append(append([]Po2(nil), points[far:]...), points[0])
+	ringKeep := make([]bool, len(ring))
+	douglasPeucker(ring, 0, len(ring)-1, tolerance, ringKeep)
+	for i := 1; i < len(ring)-1; i++ {
+		keep[far+i] = ringKeep[i]
+	}
+
+	out := keptPoints(points, keep)
+	if len(out) < 3 {
+		// a flat ring, keep the point furthest from the kept chord so it still has an area
+		best, bestD := -1, float32(-1)
+		seg := Segment2{A: points[0], B: points[far]}
+		for i, p := range points {
+			if d := seg.Distance(p); d > bestD && i != 0 && i != far {
+				best, bestD = i, d
+			}
+		}
+		if best >= 0 {
+			keep[best] = true
+			out = keptPoints(points, keep)
+		}
+	}
+	return out
+}
+
+func douglasPeucker(points []Po2, first, last int, tolerance float32, keep []bool) {
+	for last-first > 1 {
+		seg := Segment2{A: points[first], B: points[last]}
+		split, dist := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if d := seg.Distance(points[i]); d > dist {
+				split, dist = i, d
+			}
+		}
+		if split < 0 {
+			return
+		}
+
+		keep[split] = true
+		douglasPeucker(points, first, split, tolerance, keep)
+		first = split
+	}
+}
+
+func keptPoints(points []Po2, keep []bool) []Po2 {
+	var out []Po2
+	for i, k := range keep {
+		if k {
+			out = append(out, points[i])
+		}
+	}
+	return out
+}
+
+type vwEntry struct {
+	index int
+	area  float32
+	stamp int
+}
+
+type vwHeap []vwEntry
+
+func (h vwHeap) Len() int           { return len(h) }
+func (h vwHeap) Less(i, j int) bool { return h[i].area < h[j].area }
+func (h vwHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *vwHeap) Push(x any)        { *h = append(*h, x.(vwEntry)) }
+func (h *vwHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// SimplifyVisvalingam repeatedly removes the point forming the smallest triangle with its neighbours until every
+// remaining triangle is at least minArea (Visvalingam-Whyatt). It tends to keep the overall shape better than
+// Douglas-Peucker at the same point count
+func SimplifyVisvalingam(points []Po2, minArea float32, closed bool) []Po2 {
+	n := len(points)
+	minPoints := 2
+	if closed {
+		minPoints = 3
+	}
+	if n <= minPoints {
+		return append([]Po2(nil), points...)
+	}
+
+	prev := make([]int, n)
+	next := make([]int, n)
+	stamp := make([]int, n)
+	removed := make([]bool, n)
+	for i := range points {
+		prev[i] = i - 1
+		next[i] = i + 1
+	}
+	if closed {
+		prev[0] = n - 1
+		next[n-1] = 0
+	}
+
+	area := func(i int) float32 {
+		return absf(Orient(points[prev[i]], points[i], points[next[i]])) / 2
+	}
+	movable := func(i int) bool {
+		return prev[i] >= 0 && next[i] < n
+	}
+
+	h := &vwHeap{}
+	for i := range points {
+		if movable(i) {
+			heap.Push(h, vwEntry{index: i, area: area(i)})
+		}
+	}
+
+	left := n
+	for h.Len() > 0 && left > minPoints {
+		e := heap.Pop(h).(vwEntry)
+		if removed[e.index] || e.stamp != stamp[e.index] {
+			continue
+		}
+		if e.area >= minArea {
+			break
+		}
+
+		removed[e.index] = true
+		left--
+		p, q := prev[e.index], next[e.index]
+		next[p] = q
+		prev[q] = p
+
+		// neighbours get a new triangle, never smaller than the removed one so the order stays stable
+		for _, k := range [2]int{p, q} {
+			if movable(k) {
+				stamp[k]++
+				heap.Push(h, vwEntry{index: k, area: max(area(k), e.area), stamp: stamp[k]})
+			}
+		}
+	}
+
+	out := make([]Po2, 0, left)
+	for i, p := range points {
+		if !removed[i] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// SmoothChaikin rounds corners by cutting each one at a quarter and three quarters of its edges, once per
+// iteration. Open polylines keep their endpoints
+func SmoothChaikin(points []Po2, iterations int, closed bool) []Po2 {
+	out := append([]Po2(nil), points...)
+
+	for it := 0; it < iterations && len(out) >= 3; it++ {
+		n := len(out)
+		smoothed := make([]Po2, 0, n*2)
+		if !closed {
+			smoothed = append(smoothed, out[0])
+		}
+
+		edges := n
+		if !closed {
+			edges = n - 1
+		}
+		for i := 0; i < edges; i++ {
+			a := out[i]
+			b := out[(i+1)%n]
+			d := b.Sub(a)
+			smoothed = append(smoothed, a.Add(d.Mul(0.25)), a.Add(d.Mul(0.75)))
+		}
+
+		if !closed {
+			smoothed = append(smoothed, out[n-1])
+		}
+		out = smoothed
+	}
+
+	return out
+}
+
+// ResamplePolyline places points every spacing units along the line. Open polylines keep their last point,
+// closed rings wrap around and end before reaching the start again
+func ResamplePolyline(points []Po2, spacing float32, closed bool) []Po2 {
+	if len(points) < 2 || spacing <= 0 {
+		return append([]Po2(nil), points...)
+	}
+
+	path := points
+	if closed {
+		path = append(append([]Po2(nil), points...), points[0])
+	}
+
+	out := []Po2{path[0]}
+	carry := float32(0) // distance walked since the last emitted point
+
+	for i := 0; i+1 < len(path); i++ {
+		a, b := path[i], path[i+1]
+		l := a.Distance(b)
+		if l == 0 {
+			continue
+		}
+
+		d := spacing - carry
+		for d <= l {
+			out = append(out, a.Add(b.Sub(a).Mul(d/l)))
+			d += spacing
+		}
+		carry = l - (d - spacing)
+	}
+
+	last := path[len(path)-1]
+	if closed {
+		// the final point sits on or right before the start
+		if len(out) > 1 && out[len(out)-1].Distance(out[0]) < spacing*0.5 {
+			out = out[:len(out)-1]
+		}
+		return out
+	}
+
+	if out[len(out)-1].Distance(last) > spacing*0.5 {
+		out = append(out, last)
+	} else {
+		out[len(out)-1] = last
+	}
+	return out
+}
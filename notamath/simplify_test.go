@@ -0,0 +1,26 @@
+package notamath
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSimplifyDouglasPeuckerCoincidentRing(t *testing.T) {
+	ring := []Po2{{1, 1}, {1, 1}, {1, 1}, {1, 1}}
+	got := SimplifyDouglasPeucker(ring, 0.1, true)
+	if !slices.Equal(got, ring) {
+		t.Fatalf("got %v, want the input back", got)
+	}
+	got[0].X = 5
+	if ring[0].X != 1 {
+		t.Error("the result aliases the input")
+	}
+}
+
+func TestSimplifyDouglasPeuckerKeepsThreeOnRings(t *testing.T) {
+	// a nearly flat ring simplifies to its two ends, the furthest point from that chord is kept as well
+	ring := []Po2{{0, 0}, {1, 0.01}, {2, 0}, {3, 0.01}, {4, 0}, {2, -0.02}}
+	if got := SimplifyDouglasPeucker(ring, 1, true); len(got) != 3 {
+		t.Fatalf("got %d points %v, want 3", len(got), got)
+	}
+}
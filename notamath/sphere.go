@@ -0,0 +1,53 @@
+package notamath
+
+import "math"
+
+type Sphere struct {
+	Center Po3
+	Radius float32
+}
+
+// SphereFromPoints returns a sphere around the centroid enclosing every point, not the minimal one
+func SphereFromPoints(points []Po3) Sphere {
+	if len(points) == 0 {
+		return Sphere{}
+	}
+
+	var c Vec3
+	for _, p := range points {
+		c = c.Add(Vec3(p))
+	}
+	center := Po3(c.Div(float32(len(points))))
+
+	var r2 float32
+	for _, p := range points {
+		r2 = max(r2, center.DistanceSquared(p))
+	}
+	return Sphere{Center: center, Radius: float32(math.Sqrt(float64(r2)))}
+}
+
+func (s Sphere) Contains(p Po3) bool {
+	return s.Center.DistanceSquared(p) <= s.Radius*s.Radius
+}
+
+func (s Sphere) Intersects(o Sphere) bool {
+	r := s.Radius + o.Radius
+	return s.Center.DistanceSquared(o.Center) <= r*r
+}
+
+func (s Sphere) IntersectsBox(b Box3) bool {
+	return b.ClosestPoint(s.Center).DistanceSquared(s.Center) <= s.Radius*s.Radius
+}
+
+func (s Sphere) ClosestPoint(p Po3) Po3 {
+	d := p.SubPo(s.Center)
+	if d.LenSquared() <= s.Radius*s.Radius {
+		return p
+	}
+	return s.Center.Add(d.Normalize().Mul(s.Radius))
+}
+
+// Distance from the surface of the sphere, 0 for points inside
+func (s Sphere) Distance(p Po3) float32 {
+	return max(s.Center.Distance(p)-s.Radius, 0)
+}
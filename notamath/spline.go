@@ -0,0 +1,150 @@
+package notamath
+
+// CatmullRom passes through every control point, t in [0, 1] spans the whole spline
+type CatmullRom[T vector[T]] struct {
+	Points []T
+	Closed bool
+}
+
+// BSpline is a uniform cubic B-spline, smoother than CatmullRom but it only approaches its control points
+type BSpline[T vector[T]] struct {
+	Points []T
+	Closed bool
+}
+
+type (
+	CatmullRom2 = CatmullRom[Vec2]
+	CatmullRom3 = CatmullRom[Vec3]
+	BSpline2    = BSpline[Vec2]
+	BSpline3    = BSpline[Vec3]
+)
+
+func (c CatmullRom[T]) segments() int {
+	if c.Closed {
+		return len(c.Points)
+	}
+	return len(c.Points) - 1
+}
+
+// window returns the four control points around segment i, open ends repeat their end point
+func (c CatmullRom[T]) window(i int) [4]T {
+	n := len(c.Points)
+	at := func(j int) T {
+		if c.Closed {
+			return c.Points[((j%n)+n)%n]
+		}
+		return c.Points[min(max(j, 0), n-1)]
+	}
+	return [4]T{at(i - 1), at(i), at(i + 1), at(i + 2)}
+}
+
+func (c CatmullRom[T]) Point(t float32) T {
+	var zero T
+	if len(c.Points) == 0 {
+		return zero
+	}
+	if len(c.Points) == 1 {
+		return c.Points[0]
+	}
+
+	i, u := splineSegment(t, c.segments())
+	p := c.window(i)
+	u2, u3 := u*u, u*u*u
+
+	return combine(p, [4]float32{
+		0.5 * (-u3 + 2*u2 - u),
+		0.5 * (3*u3 - 5*u2 + 2),
+		0.5 * (-3*u3 + 4*u2 + u),
+		0.5 * (u3 - u2),
+	})
+}
+
+func (c CatmullRom[T]) Derivative(t float32) T {
+	var zero T
+	if len(c.Points) < 2 {
+		return zero
+	}
+
+	n := c.segments()
+	i, u := splineSegment(t, n)
+	p := c.window(i)
+	u2 := u * u
+
+	// chain rule: the segment parameter moves n times faster than t
+	return combine(p, [4]float32{
+		0.5 * (-3*u2 + 4*u - 1),
+		0.5 * (9*u2 - 10*u),
+		0.5 * (-9*u2 + 8*u + 1),
+		0.5 * (3*u2 - 2*u),
+	}).Mul(float32(n))
+}
+
+func (b BSpline[T]) segments() int {
+	if b.Closed {
+		return len(b.Points)
+	}
+	return len(b.Points) - 3
+}
+
+func (b BSpline[T]) window(i int) [4]T {
+	n := len(b.Points)
+	if b.Closed {
+		return [4]T{b.Points[i%n], b.Points[(i+1)%n], b.Points[(i+2)%n], b.Points[(i+3)%n]}
+	}
+	return [4]T{b.Points[i], b.Points[i+1], b.Points[i+2], b.Points[i+3]}
+}
+
+// Point evaluates the spline, an open spline needs at least four control points
+func (b BSpline[T]) Point(t float32) T {
+	var zero T
+	n := b.segments()
+	if n <= 0 {
+		return zero
+	}
+
+	i, u := splineSegment(t, n)
+	p := b.window(i)
+	s := 1 - u
+	u2, u3 := u*u, u*u*u
+
+	return combine(p, [4]float32{
+		s * s * s / 6,
+		(3*u3 - 6*u2 + 4) / 6,
+		(-3*u3 + 3*u2 + 3*u + 1) / 6,
+		u3 / 6,
+	})
+}
+
+func (b BSpline[T]) Derivative(t float32) T {
+	var zero T
+	n := b.segments()
+	if n <= 0 {
+		return zero
+	}
+
+	i, u := splineSegment(t, n)
+	p := b.window(i)
+	s := 1 - u
+	u2 := u * u
+
+	return combine(p, [4]float32{
+		-s * s / 2,
+		(3*u2 - 4*u) / 2,
+		(-3*u2 + 2*u + 1) / 2,
+		u2 / 2,
+	}).Mul(float32(n))
+}
+
+// splineSegment maps a global t in [0, 1] to a segment index and the local parameter inside it
+func splineSegment(t float32, segments int) (int, float32) {
+	t = clamp(t, 0, 1) * float32(segments)
+	i := int(t)
+	if i >= segments {
+		i = segments - 1
+	}
+	return i, t - float32(i)
+}
+
+func combine[T vector[T]](p [4]T, w [4]float32) T {
+	return p[0].Mul(w[0]).Add(p[1].Mul(w[1])).Add(p[2].Mul(w[2])).Add(p[3].Mul(w[3]))
+}
@@ -2,21 +2,23 @@ package notamath
 
 type Transform3D struct {
 	Position     Vec3
-	RotationAxis Vec3 // Reference vector for rotation
-	Rotation     float32
+	Rotation     Quat
 	Scale        Vec3
 	Dirty        bool // true if matrix needs to be recomputed
 	matrix       Mat4 // cached TRS matrix
 	prevPosition Vec3
-	prevRotation float32
+	prevRotation Quat
 	prevScale    Vec3
 }
 
 func NewTransform3D() Transform3D {
 	return Transform3D{
-		Scale:  Vec3{1, 1, 1},
-		Dirty:  true,
-		matrix: Mat4Identity(),
+		Rotation:     QuatIdentity(),
+		Scale:        Vec3{1, 1, 1},
+		Dirty:        true,
+		matrix:       Mat4Identity(),
+		prevRotation: QuatIdentity(),
+		prevScale:    Vec3{1, 1, 1},
 	}
 }
 
@@ -25,8 +27,18 @@ func (t *Transform3D) SetPosition(p Vec3) {
 	t.Dirty = true
 }
 
-func (t *Transform3D) SetRotationAxis(r Vec3) {
-	t.RotationAxis = r.Normalize()
+func (t *Transform3D) SetRotation(q Quat) {
+	t.Rotation = q.Normalize()
+	t.Dirty = true
+}
+
+func (t *Transform3D) SetRotationAxisAngle(axis Vec3, angle float32) {
+	t.Rotation = QuatFromAxisAngle(axis, angle)
+	t.Dirty = true
+}
+
+func (t *Transform3D) SetRotationEuler(pitch, yaw, roll float32) {
+	t.Rotation = QuatFromEuler(pitch, yaw, roll)
 	t.Dirty = true
 }
 
@@ -40,13 +52,7 @@ func (t *Transform3D) Matrix() Mat4 {
 		return t.matrix
 	}
 
-	tr := Mat4Translation(t.Position)
-
-	rot := Mat4RotationAxisAngle(t.RotationAxis, t.Rotation)
-
-	sc := Mat4Scale(t.Scale)
-
-	t.matrix = tr.SmartMul(rot).SmartMul(sc)
+	t.matrix = Mat4TRSQuat(t.Position, t.Rotation, t.Scale)
 	t.Dirty = false
 	return t.matrix
 }
@@ -64,11 +70,23 @@ func (t *Transform3D) TranslateBy(delta Vec3) {
 	t.Dirty = true
 }
 
-func (t *Transform3D) RotateBy(delta float32) {
-	t.Rotation += delta
+// RotateBy applies delta on top of the current rotation in world space
+func (t *Transform3D) RotateBy(delta Quat) {
+	t.Rotation = delta.Mul(t.Rotation).Normalize()
 	t.Dirty = true
 }
 
+// RotateLocal applies delta on top of the current rotation in the object's own space
+func (t *Transform3D) RotateLocal(delta Quat) {
+	t.Rotation = t.Rotation.Mul(delta).Normalize()
+	t.Dirty = true
+}
+
+// RotateAround rotates by angle radians around a world space axis
+func (t *Transform3D) RotateAround(axis Vec3, angle float32) {
+	t.RotateBy(QuatFromAxisAngle(axis, angle))
+}
+
 func (t *Transform3D) ScaleBy(factor Vec3) {
 	t.Scale = Vec3{t.Scale.X * factor.X, t.Scale.Y * factor.Y, t.Scale.Z * factor.Z}
 	t.Dirty = true
@@ -83,9 +101,9 @@ func (t *Transform3D) Snapshot() {
 func (t *Transform3D) InterpolatedMatrix(alpha float32) Mat4 {
 	pos := t.prevPosition.Lerp(t.Position, alpha)
 	scale := t.prevScale.Lerp(t.Scale, alpha)
-	angle := lerpAngle(t.prevRotation, t.Rotation, alpha)
+	rot := t.prevRotation.Slerp(t.Rotation, alpha)
 
-	return Mat4TRS(pos, t.RotationAxis, angle, scale)
+	return Mat4TRSQuat(pos, rot, scale)
 }
 
 type AxisMask uint8
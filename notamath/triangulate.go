@@ -0,0 +1,114 @@
+package notamath
+
+// Triangulate splits a simple polygon into triangles by ear clipping, every three points of the result form a
+// counter-clockwise triangle. It returns nil for degenerate or self intersecting input
+func Triangulate(poly []Po2) []Po2 {
+	indices, _ := TriangulateInto(nil, nil, poly)
+	if len(indices) == 0 {
+		return nil
+	}
+	result := make([]Po2, len(indices))
+	for i, idx := range indices {
+		result[i] = poly[idx]
+	}
+	return result
+}
+
+// TriangulateInto is Triangulate giving indices into poly, so callers can carry other per vertex data along. The
+// indices are appended to dst and work is scratch space, both are returned for reuse so a caller keeping them
+// between calls does not allocate. dst is left unchanged if the polygon cannot be triangulated
+func TriangulateInto(dst, work []int, poly []Po2) ([]int, []int) {
+	n := len(poly)
+	if n < 3 {
+		return dst, work
+	}
+
+	verts := resize(work, n)
+	for i := range verts {
+		verts[i] = i
+	}
+	if SignedArea(poly) < 0 {
+		for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+			verts[i], verts[j] = verts[j], verts[i]
+		}
+	}
+
+	start := len(dst)
+	for len(verts) > 3 {
+		earFound := false
+
+		for i := range verts {
+			prev := verts[(i-1+len(verts))%len(verts)]
+			curr := verts[i]
+			next := verts[(i+1)%len(verts)]
+
+			if isEarOf(prev, curr, next, verts, poly) {
+				dst = append(dst, prev, curr, next)
+				verts = append(verts[:i], verts[i+1:]...)
+				earFound = true
+				break
+			}
+		}
+
+		if !earFound {
+			return dst[:start], verts
+		}
+	}
+
+	return append(dst, verts[0], verts[1], verts[2]), verts
+}
+
+// isEarOf is IsEar for the remaining vertices of poly, given by index
+func isEarOf(prev, curr, next int, verts []int, poly []Po2) bool {
+	a, b, c := poly[prev], poly[curr], poly[next]
+	if Orient(a, b, c) <= 0 {
+		return false
+	}
+	for _, v := range verts {
+		p := poly[v]
+		if p == a || p == b || p == c {
+			continue
+		}
+		if PointInTriangle(p, a, b, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// SignedArea is positive for counter-clockwise polygons
+func SignedArea(poly []Po2) float32 {
+	var area float32
+	for i := range poly {
+		a := poly[i]
+		b := poly[(i+1)%len(poly)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return area / 2
+}
+
+func PointInTriangle(p, a, b, c Po2) bool {
+	o1 := Orient(a, b, p)
+	o2 := Orient(b, c, p)
+	o3 := Orient(c, a, p)
+
+	hasNeg := o1 < 0 || o2 < 0 || o3 < 0
+	hasPos := o1 > 0 || o2 > 0 || o3 > 0
+	return !(hasNeg && hasPos)
+}
+
+// IsEar reports whether prev, curr, next is a counter-clockwise corner of poly with no other point of poly inside
+func IsEar(prev, curr, next Po2, poly []Po2) bool {
+	if Orient(prev, curr, next) <= 0 {
+		return false
+	}
+	for _, p := range poly {
+		if p == prev || p == curr || p == next {
+			continue
+		}
+		if PointInTriangle(p, prev, curr, next) {
+			return false
+		}
+	}
+	return true
+}
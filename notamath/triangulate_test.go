@@ -0,0 +1,37 @@
+package notamath
+
+import "testing"
+
+func TestTriangulateIntoConcave(t *testing.T) {
+	// an L shape given clockwise, so it has to be flipped first
+	poly := []Po2{{0, 0}, {0, 2}, {1, 2}, {1, 1}, {2, 1}, {2, 0}}
+	indices, _ := TriangulateInto(nil, nil, poly)
+	if len(indices) != (len(poly)-2)*3 {
+		t.Fatalf("got %d indices, want %d", len(indices), (len(poly)-2)*3)
+	}
+
+	var area float32
+	for i := 0; i < len(indices); i += 3 {
+		a, b, c := poly[indices[i]], poly[indices[i+1]], poly[indices[i+2]]
+		if Orient(a, b, c) <= 0 {
+			t.Errorf("triangle %v %v %v is not counter-clockwise", a, b, c)
+		}
+		area += SignedArea([]Po2{a, b, c})
+	}
+	if area != 3 {
+		t.Errorf("triangles cover %f, want 3", area)
+	}
+
+	if got := Triangulate(poly); len(got) != len(indices) {
+		t.Errorf("Triangulate gave %d points, want %d", len(got), len(indices))
+	}
+}
+
+func TestTriangulateIntoKeepsDstOnFailure(t *testing.T) {
+	dst := []int{7, 8, 9}
+	line := []Po2{{0, 0}, {1, 0}, {2, 0}, {3, 0}}
+	got, _ := TriangulateInto(dst, nil, line)
+	if len(got) != 3 {
+		t.Errorf("dst grew to %v for a degenerate polygon", got)
+	}
+}
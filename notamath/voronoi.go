@@ -0,0 +1,80 @@
+package notamath
+
+// Voronoi returns the cell of every point clipped to bounds, in the same order as points. Each cell is a
+// counter-clockwise convex polygon holding the area closer to its point than to any other, duplicates share a cell
+func Voronoi(points []Po2, bounds Rect) [][]Po2 {
+	cells := make([][]Po2, len(points))
+	if len(points) == 0 {
+		return cells
+	}
+
+	// the Delaunay neighbours are the only points that can bound a cell
+	neighbours := make([][]int, len(points))
+	t := Delaunay(points)
+	for _, e := range t.Edges() {
+		neighbours[e[0]] = append(neighbours[e[0]], e[1])
+		neighbours[e[1]] = append(neighbours[e[1]], e[0])
+	}
+
+	first := make(map[Po2]int, len(points))
+	for i, p := range points {
+		if j, ok := first[p]; ok {
+			cells[i] = cells[j]
+			continue
+		}
+		first[p] = i
+
+		others := neighbours[i]
+		if len(t.Triangles) == 0 {
+			// collinear or tiny inputs have no triangles, compare against everything
+			others = others[:0]
+			for j := range points {
+				others = append(others, j)
+			}
+		}
+
+		cell := []Po2{
+			bounds.Min,
+			{bounds.Max.X, bounds.Min.Y},
+			bounds.Max,
+			{bounds.Min.X, bounds.Max.Y},
+		}
+		for _, j := range others {
+			if points[j] == p {
+				continue
+			}
+			cell = clipBisector(cell, p, points[j])
+			if len(cell) == 0 {
+				break
+			}
+		}
+		cells[i] = cell
+	}
+
+	return cells
+}
+
+// clipBisector keeps the part of a convex polygon that is closer to site than to other
+func clipBisector(poly []Po2, site, other Po2) []Po2 {
+	n := other.Sub(site)
+	mid := site.Add(n.Mul(0.5))
+	side := func(p Po2) float32 {
+		return p.Sub(mid).Dot(n)
+	}
+
+	out := make([]Po2, 0, len(poly)+1)
+	for i := range poly {
+		a := poly[i]
+		b := poly[(i+1)%len(poly)]
+		sa, sb := side(a), side(b)
+
+		if sa <= 0 {
+			out = append(out, a)
+		}
+		if (sa < 0 && sb > 0) || (sa > 0 && sb < 0) {
+			t := sa / (sa - sb)
+			out = append(out, a.Add(b.Sub(a).Mul(t)))
+		}
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package notamath
+
+import "math"
+
+// Worley is seedable cellular noise with one feature point per unit cell.
+// F1 is the distance to the closest feature point and F2 to the second closest
+type Worley struct {
+	seed uint64
+}
+
+func NewWorley(seed uint64) *Worley {
+	return &Worley{seed: seed}
+}
+
+func (w *Worley) Noise2(x, y float32) (f1, f2 float32) {
+	cx, cy := floorInt(x), floorInt(y)
+	f1, f2 = float32(math.MaxFloat32), float32(math.MaxFloat32)
+
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			h := w.hash(cx+dx, cy+dy, 0)
+			px := float32(cx+dx) + unitFloat(h)
+			py := float32(cy+dy) + unitFloat(h>>21)
+
+			d := (px-x)*(px-x) + (py-y)*(py-y)
+			f1, f2 = closest2(d, f1, f2)
+		}
+	}
+	return sqrt32(f1), sqrt32(f2)
+}
+
+func (w *Worley) Noise3(x, y, z float32) (f1, f2 float32) {
+	cx, cy, cz := floorInt(x), floorInt(y), floorInt(z)
+	f1, f2 = float32(math.MaxFloat32), float32(math.MaxFloat32)
+
+	for dz := -1; dz <= 1; dz++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				h := w.hash(cx+dx, cy+dy, cz+dz)
+				px := float32(cx+dx) + unitFloat(h)
+				py := float32(cy+dy) + unitFloat(h>>21)
+				pz := float32(cz+dz) + unitFloat(h>>42)
+
+				d := (px-x)*(px-x) + (py-y)*(py-y) + (pz-z)*(pz-z)
+				f1, f2 = closest2(d, f1, f2)
+			}
+		}
+	}
+	return sqrt32(f1), sqrt32(f2)
+}
+
+// F1 returns only the closest distance, so it can be used as a Noise2Func
+func (w *Worley) F1(x, y float32) float32 {
+	f1, _ := w.Noise2(x, y)
+	return f1
+}
+
+func (w *Worley) hash(x, y, z int) uint64 {
+	state := w.seed ^ uint64(int64(x))*0x9e3779b97f4a7c15 ^ uint64(int64(y))*0xc2b2ae3d27d4eb4f ^ uint64(int64(z))*0x165667b19e3779f9
+	return splitMix64(&state)
+}
+
+// unitFloat turns the low 21 bits into a float in [0, 1)
+func unitFloat(h uint64) float32 {
+	return float32(h&0x1fffff) / float32(0x200000)
+}
+
+func closest2(d, f1, f2 float32) (float32, float32) {
+	if d < f1 {
+		return d, f1
+	}
+	if d < f2 {
+		return f1, d
+	}
+	return f1, f2
+}
+
+func sqrt32(v float32) float32 {
+	return float32(math.Sqrt(float64(v)))
+}
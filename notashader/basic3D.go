@@ -5,10 +5,12 @@ const Vertex3D = `
 layout(location = 0) in vec3 aPos;
 layout(location = 1) in vec4 aColor;
 
+uniform mat4 uViewProjection;
+
 out vec4 vColor;
 
 void main() {
-    gl_Position = vec4(aPos, 1.0);
+    gl_Position = uViewProjection * vec4(aPos, 1.0);
     vColor = aColor;
 }`
 
@@ -0,0 +1,74 @@
+package notatween
+
+import (
+	"NotaborEngine/notacore"
+	"sync"
+)
+
+// Manager advances every playing animation, finished and killed ones are dropped automatically
+type Manager struct {
+	mu     sync.Mutex
+	active []Animation
+}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Play starts the animation and returns it so it can be killed later
+func (m *Manager) Play(a Animation) Animation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.active = append(m.active, a)
+	return a
+}
+
+// Update advances all animations by dt seconds
+func (m *Manager) Update(dt float32) {
+	m.mu.Lock()
+	playing := append([]Animation(nil), m.active...)
+	m.mu.Unlock()
+
+	// callbacks may call Play, so the lock is not held while advancing
+	for _, a := range playing {
+		a.advance(dt)
+	}
+
+	m.mu.Lock()
+	kept := m.active[:0]
+	for _, a := range m.active {
+		if !a.finished() {
+			kept = append(kept, a)
+		}
+	}
+	clear(m.active[len(kept):])
+	m.active = kept
+	m.mu.Unlock()
+}
+
+// KillAll stops every animation without calling their completion callbacks
+func (m *Manager) KillAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, a := range m.active {
+		a.Kill()
+	}
+	m.active = nil
+}
+
+func (m *Manager) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.active)
+}
+
+// Runnable advances the manager by one tick of the loop, add it with loop.Add. Animations follow the
+// loop's scaled time, so they slow down with its time scale and stop while it is paused
+func (m *Manager) Runnable(loop *notacore.FixedHzLoop) notacore.Runnable {
+	return func() error {
+		if dt := loop.Dt(); dt > 0 {
+			m.Update(dt)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,87 @@
+package notatween
+
+// Sequence plays animations one after another, leftover time of a finished step flows into the next one
+type Sequence struct {
+	steps      []Animation
+	index      int
+	loops      int
+	played     int
+	onComplete func()
+	done       bool
+}
+
+func NewSequence(steps ...Animation) *Sequence {
+	return &Sequence{
+		steps: steps,
+		loops: 1,
+	}
+}
+
+func (s *Sequence) Append(steps ...Animation) *Sequence {
+	s.steps = append(s.steps, steps...)
+	return s
+}
+
+// Loop sets how many times the whole sequence plays, LoopForever repeats until killed
+func (s *Sequence) Loop(times int) *Sequence {
+	s.loops = times
+	return s
+}
+
+// OnComplete runs fn once the last loop finishes, it is not called when the sequence is killed
+func (s *Sequence) OnComplete(fn func()) *Sequence {
+	s.onComplete = fn
+	return s
+}
+
+func (s *Sequence) Kill() {
+	s.done = true
+}
+
+func (s *Sequence) finished() bool {
+	return s.done
+}
+
+func (s *Sequence) restart() {
+	s.index = 0
+	s.played = 0
+	s.done = false
+	for _, step := range s.steps {
+		step.restart()
+	}
+}
+
+func (s *Sequence) advance(dt float32) float32 {
+	if s.done {
+		return dt
+	}
+
+	for {
+		passStart := dt
+		for s.index < len(s.steps) {
+			dt = s.steps[s.index].advance(dt)
+			if !s.steps[s.index].finished() {
+				return 0
+			}
+			s.index++
+		}
+
+		s.played++
+		if s.loops != LoopForever && s.played >= s.loops {
+			s.done = true
+			if s.onComplete != nil {
+				s.onComplete()
+			}
+			return dt
+		}
+
+		s.index = 0
+		for _, step := range s.steps {
+			step.restart()
+		}
+		// a pass that took no time would repeat endlessly, an empty or all instant endless sequence plays once a tick
+		if dt <= 0 || dt == passStart {
+			return 0
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package notatween
+
+import "testing"
+
+func TestSequenceCarriesLeftover(t *testing.T) {
+	var a, b float32
+	seq := NewSequence(Float(&a, 1, 1), Float(&b, 1, 1))
+
+	seq.advance(1.5)
+	if a != 1 || b != 0.5 {
+		t.Errorf("a = %f, b = %f, want 1 and 0.5", a, b)
+	}
+	if left := seq.advance(1); left != 0.5 || !seq.finished() {
+		t.Errorf("leftover %f, finished %v, want 0.5 and true", left, seq.finished())
+	}
+}
+
+func TestSequenceLoops(t *testing.T) {
+	calls, done := 0, 0
+	seq := NewSequence(Wait(1), Call(func() { calls++ })).Loop(2).OnComplete(func() { done++ })
+
+	seq.advance(1.5)
+	if calls != 1 || done != 0 {
+		t.Fatalf("after one loop: %d calls, %d completions, want 1 and 0", calls, done)
+	}
+	if left := seq.advance(1); left != 0.5 {
+		t.Errorf("leftover %f, want 0.5", left)
+	}
+	if calls != 2 || done != 1 {
+		t.Errorf("after two loops: %d calls, %d completions, want 2 and 1", calls, done)
+	}
+}
+
+func TestSequenceEndlessInstant(t *testing.T) {
+	// every pass takes no time, the sequence has to give the tick back instead of spinning
+	calls := 0
+	seq := NewSequence(Call(func() { calls++ })).Loop(LoopForever)
+	for range 3 {
+		if left := seq.advance(1); left != 0 {
+			t.Fatalf("leftover %f, an endless sequence uses the whole tick", left)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("%d calls over 3 ticks, want one pass a tick", calls)
+	}
+
+	if left := NewSequence().Loop(LoopForever).advance(1); left != 0 {
+		t.Errorf("empty endless sequence: leftover %f, want 0", left)
+	}
+}
+
+func TestManagerDropsFinished(t *testing.T) {
+	var v float32
+	m := NewManager()
+	m.Play(Float(&v, 1, 1))
+	endless := m.Play(NewSequence(Wait(1)).Loop(LoopForever))
+
+	m.Update(0.5)
+	if m.Count() != 2 {
+		t.Fatalf("Count() = %d while both play, want 2", m.Count())
+	}
+	m.Update(1)
+	if m.Count() != 1 || v != 1 {
+		t.Errorf("Count() = %d, v = %f, want the finished tween dropped at 1", m.Count(), v)
+	}
+	endless.Kill()
+	m.Update(1)
+	if m.Count() != 0 {
+		t.Errorf("Count() = %d after Kill, want 0", m.Count())
+	}
+}
@@ -0,0 +1,215 @@
+package notatween
+
+import (
+	"NotaborEngine/notamath"
+	"NotaborEngine/notashader"
+)
+
+// LoopForever makes a tween or sequence repeat until it is killed
+const LoopForever = -1
+
+// Animation is anything the Manager can play: a Tween or a Sequence
+type Animation interface {
+	advance(dt float32) float32
+	restart()
+	finished() bool
+	Kill()
+}
+
+// Tween moves a value towards a target over Duration seconds.
+// The start value is read from the target the first time the tween runs, after its delay
+type Tween struct {
+	duration   float32
+	delay      float32
+	ease       notamath.EaseFunc
+	loops      int
+	pingPong   bool
+	onComplete func()
+
+	capture func()
+	apply   func(t float32)
+
+	captured bool
+	waited   float32
+	elapsed  float32
+	played   int
+	done     bool
+}
+
+func newTween(duration float32, capture func(), apply func(t float32)) *Tween {
+	return &Tween{
+		duration: duration,
+		ease:     notamath.Linear,
+		loops:    1,
+		capture:  capture,
+		apply:    apply,
+	}
+}
+
+func valueTween[T any](ptr *T, to T, duration float32, lerp func(a, b T, t float32) T) *Tween {
+	var from T
+	return newTween(duration,
+		func() { from = *ptr },
+		func(t float32) { *ptr = lerp(from, to, t) },
+	)
+}
+
+func Float(ptr *float32, to float32, duration float32) *Tween {
+	return valueTween(ptr, to, duration, func(a, b, t float32) float32 { return a + (b-a)*t })
+}
+
+func Vec2(ptr *notamath.Vec2, to notamath.Vec2, duration float32) *Tween {
+	return valueTween(ptr, to, duration, notamath.Vec2.Lerp)
+}
+
+func Vec3(ptr *notamath.Vec3, to notamath.Vec3, duration float32) *Tween {
+	return valueTween(ptr, to, duration, notamath.Vec3.Lerp)
+}
+
+// Color interpolates each channel, unlike Color.Lerp it does not clamp t so overshooting eases work
+func Color(ptr *notashader.Color, to notashader.Color, duration float32) *Tween {
+	return valueTween(ptr, to, duration, func(a, b notashader.Color, t float32) notashader.Color {
+		return notashader.Color{
+			R: a.R + (b.R-a.R)*t,
+			G: a.G + (b.G-a.G)*t,
+			B: a.B + (b.B-a.B)*t,
+			A: a.A + (b.A-a.A)*t,
+		}
+	})
+}
+
+func Position(tr *notamath.Transform2D, to notamath.Vec2, duration float32) *Tween {
+	var from notamath.Vec2
+	return newTween(duration,
+		func() { from = tr.Position },
+		func(t float32) { tr.SetPosition(from.Lerp(to, t)) },
+	)
+}
+
+// Rotation turns the transform to the given angle in radians, without wrapping so full turns are possible
+func Rotation(tr *notamath.Transform2D, to float32, duration float32) *Tween {
+	var from float32
+	return newTween(duration,
+		func() { from = tr.Rotation },
+		func(t float32) { tr.SetRotation(from + (to-from)*t) },
+	)
+}
+
+func Scale(tr *notamath.Transform2D, to notamath.Vec2, duration float32) *Tween {
+	var from notamath.Vec2
+	return newTween(duration,
+		func() { from = tr.Scale },
+		func(t float32) { tr.SetScale(from.Lerp(to, t)) },
+	)
+}
+
+// Func calls fn with the eased progress every update, for values that are not covered by the other constructors
+func Func(fn func(t float32), duration float32) *Tween {
+	return newTween(duration, func() {}, fn)
+}
+
+// Wait is a tween that does nothing, useful as a pause inside a sequence
+func Wait(duration float32) *Tween {
+	return newTween(duration, func() {}, func(float32) {})
+}
+
+// Call is an instant step that runs fn, useful inside a sequence
+func Call(fn func()) *Tween {
+	return Wait(0).OnComplete(fn)
+}
+
+func (tw *Tween) Ease(f notamath.EaseFunc) *Tween {
+	tw.ease = f
+	return tw
+}
+
+// Delay waits d seconds before the first play, it is not repeated on loops
+func (tw *Tween) Delay(d float32) *Tween {
+	tw.delay = d
+	return tw
+}
+
+// Loop sets how many times the tween plays, LoopForever repeats until killed
+func (tw *Tween) Loop(times int) *Tween {
+	tw.loops = times
+	return tw
+}
+
+// PingPong plays every second loop backwards
+func (tw *Tween) PingPong() *Tween {
+	tw.pingPong = true
+	return tw
+}
+
+// OnComplete runs fn once the last loop finishes, it is not called when the tween is killed
+func (tw *Tween) OnComplete(fn func()) *Tween {
+	tw.onComplete = fn
+	return tw
+}
+
+func (tw *Tween) Kill() {
+	tw.done = true
+}
+
+func (tw *Tween) finished() bool {
+	return tw.done
+}
+
+func (tw *Tween) restart() {
+	tw.waited = 0
+	tw.elapsed = 0
+	tw.played = 0
+	tw.done = false
+}
+
+func (tw *Tween) advance(dt float32) float32 {
+	if tw.done {
+		return dt
+	}
+
+	if tw.waited < tw.delay {
+		tw.waited += dt
+		if tw.waited < tw.delay {
+			return 0
+		}
+		dt = tw.waited - tw.delay
+	}
+
+	if !tw.captured {
+		tw.capture()
+		tw.captured = true
+	}
+
+	for {
+		tw.elapsed += dt
+		if tw.duration > 0 && tw.elapsed < tw.duration {
+			tw.set(tw.elapsed / tw.duration)
+			return 0
+		}
+
+		dt = tw.elapsed - max(tw.duration, 0)
+		tw.set(1)
+		tw.played++
+
+		if tw.loops != LoopForever && tw.played >= tw.loops {
+			tw.done = true
+			if tw.onComplete != nil {
+				tw.onComplete()
+			}
+			return dt
+		}
+
+		tw.elapsed = 0
+		if tw.duration <= 0 {
+			// an endless instant tween would never leave this loop
+			return 0
+		}
+	}
+}
+
+func (tw *Tween) set(progress float32) {
+	if tw.pingPong && tw.played%2 == 1 {
+		progress = 1 - progress
+	}
+	tw.apply(tw.ease(progress))
+}
@@ -0,0 +1,95 @@
+package notatween
+
+import "testing"
+
+func TestTweenDelayCarriesOver(t *testing.T) {
+	var v float32
+	tw := Float(&v, 10, 1).Delay(0.5)
+
+	if left := tw.advance(0.25); left != 0 || v != 0 {
+		t.Fatalf("during the delay: v = %f, leftover %f, want 0 and 0", v, left)
+	}
+	// 0.25 finishes the delay, the other 0.5 already moves the value
+	tw.advance(0.75)
+	if v != 5 {
+		t.Errorf("v = %f after the delay, want 5", v)
+	}
+}
+
+func TestTweenStartsFromCurrentValue(t *testing.T) {
+	v := float32(2)
+	tw := Float(&v, 4, 1).Delay(1)
+	tw.advance(0.5)
+	v = 6 // changed during the delay, the tween picks it up
+	tw.advance(1)
+	if v != 5 {
+		t.Errorf("v = %f, want halfway from 6 to 4", v)
+	}
+}
+
+func TestTweenLoopsAndLeftover(t *testing.T) {
+	var v float32
+	tw := Float(&v, 4, 1).Loop(3)
+
+	tw.advance(2.5)
+	if v != 2 || tw.finished() {
+		t.Fatalf("after 2.5s of 3 loops: v = %f, finished %v, want 2 and false", v, tw.finished())
+	}
+	if left := tw.advance(1); left != 0.5 || !tw.finished() || v != 4 {
+		t.Errorf("last loop: leftover %f, finished %v, v = %f, want 0.5, true, 4", left, tw.finished(), v)
+	}
+	if left := tw.advance(1); left != 1 {
+		t.Errorf("a finished tween used %f of 1s", 1-left)
+	}
+}
+
+func TestTweenPingPong(t *testing.T) {
+	var v float32
+	tw := Float(&v, 8, 1).Loop(2).PingPong()
+
+	tw.advance(0.25)
+	if v != 2 {
+		t.Errorf("forwards: v = %f, want 2", v)
+	}
+	tw.advance(1)
+	if v != 6 {
+		t.Errorf("backwards: v = %f, want 6", v)
+	}
+	tw.advance(1)
+	if v != 0 || !tw.finished() {
+		t.Errorf("end: v = %f, finished %v, want back at 0 and finished", v, tw.finished())
+	}
+}
+
+func TestTweenOnComplete(t *testing.T) {
+	var v float32
+	calls := 0
+	tw := Float(&v, 1, 1).Loop(2).OnComplete(func() { calls++ })
+	tw.advance(1.5)
+	if calls != 0 {
+		t.Fatalf("OnComplete ran after the first of two loops")
+	}
+	tw.advance(1)
+	tw.advance(1)
+	if calls != 1 {
+		t.Errorf("OnComplete ran %d times, want once", calls)
+	}
+
+	killed := Float(&v, 1, 1).OnComplete(func() { calls++ })
+	killed.Kill()
+	killed.advance(2)
+	if calls != 1 {
+		t.Error("OnComplete ran for a killed tween")
+	}
+}
+
+func TestTweenEndlessInstant(t *testing.T) {
+	calls := 0
+	tw := Call(func() { calls++ }).Loop(LoopForever)
+	if left := tw.advance(1); left != 0 {
+		t.Errorf("leftover %f, an endless tween uses the whole tick", left)
+	}
+	if calls != 0 {
+		t.Errorf("OnComplete ran %d times for an endless tween", calls)
+	}
+}